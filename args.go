@@ -0,0 +1,52 @@
+package i18n
+
+import (
+	"maps"
+
+	"golang.org/x/text/message"
+)
+
+// Argument is a named template argument, see the Arg.
+type Argument struct {
+	name  string
+	value any
+}
+
+// Arg returns a named argument of the translation template,
+// so the template data could be passed without declaring a struct:
+// <code>
+// bundle.T(i18n.English, "greeting.hello_name", i18n.Arg("Name", "Mateo"), i18n.Arg("Count", 3))
+// </code>
+// Arguments are combined into the map[string]any template data.
+// If the map[string]any data is passed too, arguments are added to its copy;
+// data of any other type is replaced by the arguments.
+func Arg(name string, value any) Argument {
+	return Argument{name: name, value: value}
+}
+
+// Tf finds a translation for a key and formats it as a printf-style format string
+// with the arguments, e.g. "Hello, %s! You have %[2]d new messages.".
+// Numbers are formatted using the language rules.
+func (b *Bundle) Tf(lang Tag, key string, args ...any) string {
+	if lang == Und {
+		lang = b.fallbackLanguage
+	}
+
+	return message.NewPrinter(lang.Tag).Sprintf(b.Translate(lang, key), args...)
+}
+
+// mergeArgs combines the arguments with the template data.
+func mergeArgs(data any, args map[string]any) any {
+	if args == nil {
+		return data
+	}
+
+	if m, ok := data.(map[string]any); ok {
+		merged := maps.Clone(m)
+		maps.Copy(merged, args)
+
+		return merged
+	}
+
+	return args
+}
@@ -0,0 +1,85 @@
+package i18n_test
+
+import (
+	"testing"
+
+	"github.com/kukymbr/i18n"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBundle_TranslateWithArgs(t *testing.T) {
+	bundle, err := i18n.NewBundle(i18n.English, i18n.FromString(i18n.YAML, `
+translations:
+  cart: "{{ .Name }}, you have {{ .Count }} items"
+`))
+	require.NoError(t, err)
+
+	tests := []struct {
+		Name     string
+		Data     []any
+		Expected string
+	}{
+		{
+			Name:     "args",
+			Data:     []any{i18n.Arg("Name", "Mateo"), i18n.Arg("Count", 3)},
+			Expected: "Mateo, you have 3 items",
+		},
+		{
+			Name:     "map",
+			Data:     []any{map[string]any{"Name": "Mateo", "Count": 3}},
+			Expected: "Mateo, you have 3 items",
+		},
+		{
+			Name:     "map with args",
+			Data:     []any{map[string]any{"Name": "Mateo", "Count": 3}, i18n.Arg("Count", 5)},
+			Expected: "Mateo, you have 5 items",
+		},
+		{
+			Name:     "struct with args",
+			Data:     []any{struct{ Title string }{"Ignored"}, i18n.Arg("Name", "Mateo"), i18n.Arg("Count", 1)},
+			Expected: "Mateo, you have 1 items",
+		},
+		{
+			Name:     "args with engine",
+			Data:     []any{i18n.Arg("Name", "O'Brien"), i18n.TextEngine, i18n.Arg("Count", 2)},
+			Expected: "O'Brien, you have 2 items",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			assert.Equal(t, test.Expected, bundle.T(i18n.English, "cart", test.Data...))
+		})
+	}
+
+	t.Run("when map is not modified", func(t *testing.T) {
+		data := map[string]any{"Name": "Mateo", "Count": 3}
+
+		bundle.T(i18n.English, "cart", data, i18n.Arg("Count", 5))
+
+		assert.Equal(t, 3, data["Count"])
+	})
+}
+
+func TestBundle_Tf(t *testing.T) {
+	bundle, err := i18n.NewBundle(
+		i18n.English,
+		i18n.FromString(i18n.YAML, `
+language: en
+translations:
+  inbox: "Hello, %s! You have %d new messages."
+`),
+		i18n.FromString(i18n.YAML, `
+language: de
+translations:
+  inbox: "Sie haben %[2]d neue Nachrichten, %[1]s."
+`),
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t, "Hello, Mateo! You have 1,024 new messages.", bundle.Tf(i18n.English, "inbox", "Mateo", 1024))
+	assert.Equal(t, "Sie haben 1.024 neue Nachrichten, Mateo.", bundle.Tf(i18n.German, "inbox", "Mateo", 1024))
+	assert.Equal(t, "Hello, Mateo! You have 2 new messages.", bundle.Tf(i18n.Spanish, "inbox", "Mateo", 2))
+	assert.Equal(t, "unknown 42", bundle.Tf(i18n.English, "unknown %d", 42))
+}
@@ -3,7 +3,9 @@ package i18n
 import (
 	"crypto/sha256"
 	"encoding/hex"
+	"errors"
 	"fmt"
+	"maps"
 	"slices"
 	"strings"
 	"sync"
@@ -14,22 +16,44 @@ import (
 // Bundle is an i18n translations bundle.
 type Bundle struct {
 	fallbackLanguage Tag
-	translations     map[Tag]Translations
-
-	hashMu sync.RWMutex
-	hash   string
+	cfg              bundleConfig
+
+	mu            sync.RWMutex
+	translations  map[Tag]Translations
+	origins       map[Tag]map[string]*layer
+	layers        map[string]*layer
+	loading       *layer
+	layersAdded   int
+	duplicates    []*DuplicateKeyError
+	duplicatesIdx map[duplicateKey]*DuplicateKeyError
+	// warnings are the errors found under the lock, passed to the handleError after the unlocking.
+	warnings  []error
+	lazy      *lazyLoader
+	templates *templateCache
+	// loggedTemplateErrors are the languages and keys of the template errors written to the standard logger.
+	loggedTemplateErrors sync.Map
+
+	structParser     *tagsparser.Parser
+	structParserOnce sync.Once
+
+	parent *Bundle
+
+	hashMu   sync.RWMutex
+	hash     string
+	hashBase string
 }
 
 // NewBundle creates a new Bundle instance.
+// Translations from the sources not wrapped with the InLayer are added to the DefaultLayer.
+// Options (the With* functions) are applied in the same order as sources.
+//
+// All the sources are loaded even if some of them fail,
+// so the returned error joins all the loading problems (see GetLoadErrors).
 func NewBundle(fallbackLanguage Tag, sources ...BundleSource) (*Bundle, error) {
-	b := &Bundle{fallbackLanguage: fallbackLanguage}
-
-	b.translations = make(map[Tag]Translations)
+	b := newBundle(fallbackLanguage)
 
-	for _, source := range sources {
-		if err := source(b); err != nil {
-			return nil, err
-		}
+	if err := b.load(sources...); err != nil {
+		return nil, err
 	}
 
 	return b, nil
@@ -43,13 +67,26 @@ func NewEmptyBundle() *Bundle {
 }
 
 // Translate finds a translation for a key.
+// If the key is not found, it is returned as is, without rendering it as a template.
+// The first of tplData values is used as a template data;
+// a TemplateEngine value overrides the bundle's engine for this translation.
+//
+// If the template of the translation fails, the error is passed to the bundle's error handler
+// (see WithErrorHandler) and the raw text of the translation is returned.
 func (b *Bundle) Translate(lang Tag, key string, tplData ...any) string {
-	var data any
-	if len(tplData) > 0 {
-		data = tplData[0]
+	text, err := b.TranslateE(lang, key, tplData...)
+	if err != nil {
+		b.handleError(err)
 	}
 
-	return b.translate(lang, key, data)
+	return text
+}
+
+// TranslateE finds a translation for a key same as the Translate,
+// but returns the TemplateError instead of passing it to the error handler.
+// The raw text of the translation is returned with the error.
+func (b *Bundle) TranslateE(lang Tag, key string, tplData ...any) (string, error) {
+	return b.translate(lang, key, b.parseTranslateParams(tplData))
 }
 
 // T is a short alias for a Translate.
@@ -63,11 +100,21 @@ func (b *Bundle) T(lang Tag, key string, tplData ...any) string {
 // The `i18n:"field.key"` tag format is expected to get a field's translation key;
 // if no `i18n ` is found, the field's value is used as a key.
 // Add `i18n:"-"` tag to skip field's translation.
-// Only string values are affected.
+// Only string values of the exported fields are affected.
+//
+// Tag options:
+//   - `i18n:",prefix=errors."` adds the prefix to the key of the field or to the keys of the nested structure;
+//     use the blank field to set the prefix of the whole structure: _ struct{} `i18n:",prefix=errors."`;
+//   - `i18n:",fromfield=Code"` uses the value of another field as a key;
+//   - `i18n:",data=Params"` uses another field as the template data instead of the tplData.
+//
+// Nested and embedded structures, pointers and structures inside slices and maps are translated recursively.
+// String slice elements and map values are translated only if the WithStructCollections option is set.
+// Values implementing the Translatable interface translate themselves.
 func (b *Bundle) TranslateStruct(lang Tag, structure any, tplData ...any) error {
-	err := tagsparser.ParseTags(structure, func(s string) string {
-		return b.Translate(lang, s, tplData...)
-	})
+	tr := &translator{bundle: b, lang: lang, tplData: tplData}
+
+	err := b.getStructParser().Parse(structure, tr.update, tr.translateCustom)
 	if err != nil {
 		return fmt.Errorf("translate structure: %w", err)
 	}
@@ -75,6 +122,142 @@ func (b *Bundle) TranslateStruct(lang Tag, structure any, tplData ...any) error
 	return nil
 }
 
+// TranslateSlice translates all the structures of the slice same as the TranslateStruct.
+// The items argument must be a slice of structures or of pointers to structures.
+func (b *Bundle) TranslateSlice(lang Tag, items any, tplData ...any) error {
+	tr := &translator{bundle: b, lang: lang, tplData: tplData}
+
+	err := b.getStructParser().ParseSlice(items, tr.update, tr.translateCustom)
+	if err != nil {
+		return fmt.Errorf("translate slice: %w", err)
+	}
+
+	return nil
+}
+
+// AddLayer loads translations from the sources into the named layer of the existing bundle.
+// Sources are read before the bundle is locked, so translating is not blocked during the loading.
+// See InLayer for the precedence rules.
+func (b *Bundle) AddLayer(name string, priority int, sources ...BundleSource) error {
+	scratch := newBundle(b.fallbackLanguage)
+	scratch.cfg = b.cfg
+
+	err := InLayer(name, priority, sources...)(scratch)
+	b.handleErrors(scratch.takeWarnings())
+
+	if err != nil {
+		return err
+	}
+
+	loaded := scratch.layers[name]
+
+	b.mu.Lock()
+
+	l, err := b.getOrCreateLayer(name, priority)
+	if err == nil {
+		err = b.checkDuplicates(append(scratch.duplicates, l.findDuplicates(loaded)...))
+	}
+
+	if err != nil {
+		b.mu.Unlock()
+
+		return err
+	}
+
+	b.duplicates = append(b.duplicates, scratch.duplicates...)
+	b.loading = l
+
+	for lang, translations := range loaded.translations {
+		for key, text := range translations {
+			b.addTranslation(lang, key, text, loaded.positions[lang][key])
+		}
+	}
+
+	b.loading = nil
+	warnings := b.takeWarnings()
+
+	b.mu.Unlock()
+
+	b.handleErrors(warnings)
+	b.resetHash()
+	b.templates.reset()
+	b.precompileTemplates(getSortedKeys(loaded.translations, compareTags)...)
+
+	return nil
+}
+
+// RemoveLayer removes the named layer and all its translations from the bundle.
+// Returns false if there is no such layer.
+func (b *Bundle) RemoveLayer(name string) bool {
+	b.mu.Lock()
+
+	if _, ok := b.layers[name]; !ok {
+		b.mu.Unlock()
+
+		return false
+	}
+
+	if b.lazy != nil {
+		b.lazy.removeLayer(b.layers[name])
+	}
+
+	delete(b.layers, name)
+	b.rebuild()
+
+	b.mu.Unlock()
+
+	b.resetHash()
+
+	return true
+}
+
+// GetLayers returns names of the bundle's layers, ordered from the lowest precedence to the highest.
+func (b *Bundle) GetLayers() []string {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	sorted := sortLayers(b.layers)
+	names := make([]string, 0, len(sorted))
+
+	for _, l := range sorted {
+		names = append(names, l.name)
+	}
+
+	return names
+}
+
+// GetTranslationOrigin returns an Origin of the translation for the exact language and key.
+func (b *Bundle) GetTranslationOrigin(lang Tag, key string) (Origin, bool) {
+	if lang == Und {
+		lang = b.fallbackLanguage
+	}
+
+	release := b.ensureLanguage(lang)
+	defer release()
+
+	return b.getLoadedOrigin(lang, key)
+}
+
+// getLoadedOrigin returns the origin of the translation without loading the lazy files of the language.
+func (b *Bundle) getLoadedOrigin(lang Tag, key string) (Origin, bool) {
+	var origin Origin
+
+	b.mu.RLock()
+
+	l, ok := b.origins[lang][key]
+	if ok {
+		origin = l.origin(lang, key)
+	}
+
+	b.mu.RUnlock()
+
+	if !ok && b.parent != nil {
+		return b.parent.getLoadedOrigin(lang, key)
+	}
+
+	return origin, ok
+}
+
 // GetFallbackLanguage returns the fallback language.
 func (b *Bundle) GetFallbackLanguage() Tag {
 	return b.fallbackLanguage
@@ -82,10 +265,16 @@ func (b *Bundle) GetFallbackLanguage() Tag {
 
 // CalcHash calculates hash of the whole bundle.
 // Calculates hash once per instance.
+// Hash of the derived bundle (see Derive) includes the hash of its base bundle.
 func (b *Bundle) CalcHash() string {
+	baseHash := ""
+	if b.parent != nil {
+		baseHash = b.parent.CalcHash()
+	}
+
 	b.hashMu.RLock()
 
-	if b.hash != "" {
+	if b.hash != "" && b.hashBase == baseHash {
 		b.hashMu.RUnlock()
 
 		return b.hash
@@ -96,15 +285,32 @@ func (b *Bundle) CalcHash() string {
 	b.hashMu.Lock()
 	defer b.hashMu.Unlock()
 
+	// Lazy languages could be unloaded concurrently, so repeat until all of them are loaded.
+	for {
+		b.ensureAllLanguages()
+
+		b.mu.RLock()
+
+		if b.lazy == nil || b.lazy.isComplete() {
+			break
+		}
+
+		b.mu.RUnlock()
+	}
+
+	defer b.mu.RUnlock()
+
 	// Prepare sorted list of tags to avoid random hash changes because of unstable map keys order.
-	tags := getSortedKeys(b.translations, func(a Tag, b Tag) int {
-		return strings.Compare(a.String(), b.String())
-	})
+	tags := getSortedKeys(b.translations, compareTags)
 
 	hasher := sha256.New()
 
 	hasher.Write([]byte("_fallback:" + b.fallbackLanguage.String() + ";"))
 
+	if baseHash != "" {
+		hasher.Write([]byte("_base:" + baseHash + ";"))
+	}
+
 	for _, tag := range tags {
 		keys := getSortedKeys(b.translations[tag], strings.Compare)
 
@@ -114,6 +320,7 @@ func (b *Bundle) CalcHash() string {
 	}
 
 	b.hash = hex.EncodeToString(hasher.Sum(nil))
+	b.hashBase = baseHash
 
 	return b.hash
 }
@@ -128,53 +335,332 @@ func (b *Bundle) GetBundleExport(filters ...TranslationsFilterFunc) BundleExport
 	return NewBundleExport(b, filters...)
 }
 
-// Translate finds a translation for a key.
-func (b *Bundle) translate(lang Tag, key string, tplData any) string {
+// translate finds a translation for a key and renders it.
+// If there is no translation, the key itself is returned: it may come from the structure's fields
+// or from the user's input, so it is never rendered as a template.
+func (b *Bundle) translate(lang Tag, key string, params translateParams) (string, error) {
+	resolved, k, text, ok := b.lookup(lang, key)
+	if !ok {
+		return key, nil
+	}
+
+	return b.render(lang, resolved, k, text, params)
+}
+
+// lookup finds the translation of the key in the language, then in the fallback language,
+// reporting the missing one. Returns the language and the key the translation is found by.
+func (b *Bundle) lookup(lang Tag, key string) (Tag, string, string, bool) {
 	if lang == Und {
 		lang = b.fallbackLanguage
 	}
 
-	keys := []string{
-		key,
-		strings.ToLower(key),
+	search := lang
+	if b.cfg.pseudoLocales && isPseudoLocale(lang) {
+		search = b.fallbackLanguage
+	}
+
+	// The language is pinned, so it is not evicted by another goroutine until the translation is found.
+	release := b.ensureLanguage(search)
+	defer release()
+
+	if k, text, ok := b.findTranslation(search, key); ok {
+		return search, k, text, true
 	}
 
-	for _, k := range keys {
-		text, ok := b.getTranslation(lang, k)
-		if ok {
-			return prepareText(k, text, tplData)
+	if search != b.fallbackLanguage {
+		// The fallback language is never evicted.
+		b.ensureLanguage(b.fallbackLanguage)()
+
+		if k, text, ok := b.findTranslation(b.fallbackLanguage, key); ok {
+			b.reportMissing(lang, key, b.fallbackLanguage)
+
+			return b.fallbackLanguage, k, text, true
 		}
 	}
 
-	if lang != b.fallbackLanguage {
-		return b.translate(b.fallbackLanguage, key, tplData)
+	b.reportMissing(lang, key, Und)
+
+	return Und, "", "", false
+}
+
+// render renders the text of the requested language's translation, found in the resolved language.
+func (b *Bundle) render(lang Tag, resolved Tag, key string, text string, params translateParams) (string, error) {
+	if b.cfg.pseudoLocales && isPseudoLocale(lang) {
+		resolved, text = lang, pseudoLocalize(lang, text, b.cfg.pseudoExpansion)
+	}
+
+	params.lang = lang
+	if params.lang == Und {
+		params.lang = b.fallbackLanguage
 	}
 
-	return prepareText(key, key, tplData)
+	return b.prepareText(resolved, key, text, params)
+}
+
+// findTranslation returns the translation of the key or of the lowercased key, and the key found.
+func (b *Bundle) findTranslation(lang Tag, key string) (string, string, bool) {
+	for _, k := range []string{key, strings.ToLower(key)} {
+		if text, ok := b.getTranslation(lang, k); ok {
+			return k, text, true
+		}
+	}
+
+	return "", "", false
 }
 
 func (b *Bundle) getTranslation(lang Tag, key string) (string, bool) {
+	b.mu.RLock()
 	text, ok := b.translations[lang][key]
+	b.mu.RUnlock()
+
+	if !ok && b.parent != nil {
+		return b.parent.getTranslation(lang, key)
+	}
 
 	return text, ok
 }
 
+// getLanguageTranslations returns a filtered copy of the language translations.
+func (b *Bundle) getLanguageTranslations(lang Tag, filters ...TranslationsFilterFunc) (Translations, bool) {
+	var (
+		result Translations
+		found  bool
+	)
+
+	if b.parent != nil {
+		result, found = b.parent.getLanguageTranslations(lang, filters...)
+	}
+
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	translations, ok := b.translations[lang]
+	if !ok {
+		return result, found
+	}
+
+	if result == nil {
+		return FilterTranslations(translations, filters...), true
+	}
+
+	maps.Copy(result, FilterTranslations(translations, filters...))
+
+	return result, true
+}
+
+// getLanguages returns the list of languages having translations.
+func (b *Bundle) getLanguages() []Tag {
+	b.mu.RLock()
+	languages := maps.Clone(b.translations)
+	b.mu.RUnlock()
+
+	if b.parent != nil {
+		for _, lang := range b.parent.getLanguages() {
+			if _, ok := languages[lang]; !ok {
+				languages[lang] = nil
+			}
+		}
+	}
+
+	return getSortedKeys(languages, compareTags)
+}
+
+// load applies all the sources to the new bundle, joining their errors.
+func (b *Bundle) load(sources ...BundleSource) error {
+	errs := make([]error, 0, len(sources)+1)
+
+	for _, source := range sources {
+		errs = append(errs, source(b))
+	}
+
+	b.handleErrors(b.takeWarnings())
+
+	errs = append(errs, b.checkDuplicates(b.duplicates))
+
+	if err := errors.Join(errs...); err != nil {
+		return err
+	}
+
+	b.logBundleLoaded()
+	b.precompileTemplates(getSortedKeys(b.translations, compareTags)...)
+
+	if b.cfg.validate {
+		if err := errors.Join(b.loadAllLanguages()...); err != nil {
+			return err
+		}
+
+		return b.Validate()
+	}
+
+	return nil
+}
+
 func (b *Bundle) addTranslations(lang Tag, translations Translations) {
-	for key, text := range translations {
-		b.addTranslation(lang, key, text)
+	for _, key := range getSortedKeys(translations, strings.Compare) {
+		b.addTranslation(lang, key, translations[key], Position{})
 	}
+
+	b.logLoaded("", lang, len(translations))
 }
 
-func (b *Bundle) addTranslation(lang Tag, key string, text string) {
+func (b *Bundle) addFile(file *translationsFile) {
+	lang := file.lang
 	if lang == Und {
 		lang = b.fallbackLanguage
 	}
 
-	if _, ok := b.translations[lang]; !ok {
-		b.translations[lang] = make(Translations)
+	for _, def := range file.definitions {
+		if shadowed, ok := file.shadowed[def.key]; ok {
+			b.reportDuplicate(b.getLoadingLayer(), lang, def.key, append(shadowed, def.pos)...)
+		}
+
+		b.addTranslation(lang, def.key, def.text, def.pos)
 	}
 
-	b.translations[lang][key] = text
+	b.logLoaded(file.path, lang, len(file.definitions))
+}
+
+func (b *Bundle) addTranslation(lang Tag, key string, text string, pos Position) {
+	if lang == Und {
+		lang = b.fallbackLanguage
+	}
+
+	l := b.getLoadingLayer()
+
+	if _, exists := l.translations[lang][key]; exists {
+		b.reportDuplicate(l, lang, key, l.positions[lang][key], pos)
+
+		if b.cfg.duplicatePolicy == DuplicatesFirstWins {
+			return
+		}
+	}
+
+	l.set(lang, key, text, pos)
+
+	if current, ok := b.origins[lang][key]; ok {
+		if !l.overrides(current) {
+			return
+		}
+
+		if current != l {
+			b.logOverride(lang, key, pos, l, current)
+		}
+	}
+
+	b.setMerged(lang, key, text, l)
+}
+
+func (b *Bundle) setMerged(lang Tag, key string, text string, l *layer) {
+	setTranslation(b.translations, lang, key, text)
+
+	if _, ok := b.origins[lang]; !ok {
+		b.origins[lang] = make(map[string]*layer)
+	}
+
+	b.origins[lang][key] = l
+}
+
+// remerge sets the merged translation of the key from the highest layer having it,
+// or removes the translation if there are no such layers.
+func (b *Bundle) remerge(lang Tag, key string) {
+	var top *layer
+
+	for _, l := range b.layers {
+		if _, ok := l.translations[lang][key]; ok && (top == nil || l.overrides(top)) {
+			top = l
+		}
+	}
+
+	if top != nil {
+		b.setMerged(lang, key, top.translations[lang][key], top)
+
+		return
+	}
+
+	delete(b.translations[lang], key)
+	delete(b.origins[lang], key)
+
+	if len(b.translations[lang]) == 0 {
+		delete(b.translations, lang)
+		delete(b.origins, lang)
+	}
+}
+
+// rebuild merges all the layers' translations from scratch.
+func (b *Bundle) rebuild() {
+	b.templates.reset()
+
+	b.translations = make(map[Tag]Translations)
+	b.origins = make(map[Tag]map[string]*layer)
+
+	for _, l := range sortLayers(b.layers) {
+		for lang, translations := range l.translations {
+			for key, text := range translations {
+				b.setMerged(lang, key, text, l)
+			}
+		}
+	}
+}
+
+func (b *Bundle) getLoadingLayer() *layer {
+	if b.loading != nil {
+		return b.loading
+	}
+
+	return b.getDefaultLayer()
+}
+
+func (b *Bundle) getDefaultLayer() *layer {
+	if l, ok := b.layers[DefaultLayer]; ok {
+		return l
+	}
+
+	l, _ := b.getOrCreateLayer(DefaultLayer, PriorityBase)
+
+	return l
+}
+
+func (b *Bundle) getOrCreateLayer(name string, priority int) (*layer, error) {
+	if l, ok := b.layers[name]; ok {
+		if l.priority != priority {
+			return nil, fmt.Errorf("layer %s already exists with priority %d", name, l.priority)
+		}
+
+		return l, nil
+	}
+
+	l := newLayer(name, priority, b.layersAdded)
+
+	b.layers[name] = l
+	b.layersAdded++
+
+	return l, nil
+}
+
+func (b *Bundle) resetHash() {
+	b.hashMu.Lock()
+	defer b.hashMu.Unlock()
+
+	b.hash = ""
+}
+
+func newBundle(fallbackLanguage Tag) *Bundle {
+	return &Bundle{
+		fallbackLanguage: fallbackLanguage,
+		translations:     make(map[Tag]Translations),
+		origins:          make(map[Tag]map[string]*layer),
+		layers:           make(map[string]*layer),
+		duplicatesIdx:    make(map[duplicateKey]*DuplicateKeyError),
+		templates:        newTemplateCache(),
+	}
+}
+
+func setTranslation(target map[Tag]Translations, lang Tag, key string, text string) {
+	if _, ok := target[lang]; !ok {
+		target[lang] = make(Translations)
+	}
+
+	target[lang][key] = text
 }
 
 // Translations is a map of translations in a key:text format
@@ -196,6 +682,10 @@ func (t Translations) ToDict(trimPrefixes ...string) map[string]string {
 	return result
 }
 
+func compareTags(a Tag, b Tag) int {
+	return strings.Compare(a.String(), b.String())
+}
+
 func getSortedKeys[Tk comparable, Tv any](s map[Tk]Tv, compareFunc func(a Tk, b Tk) int) []Tk {
 	keys := make([]Tk, 0, len(s))
 	for k := range s {
@@ -2,53 +2,58 @@ package i18n
 
 import (
 	"embed"
+	"errors"
 	"fmt"
 	"io"
 )
 
 // BundleSource is a function adding Translations into the Bundle.
+// Options (the With* functions) are the BundleSource functions configuring the Bundle instead,
+// the ones affecting the loading must be passed to the NewBundle before the sources.
 type BundleSource func(b *Bundle) error
 
 // FromDirs reads Translations from the specified directories.
+// All the files are read even if some of them are failed to load;
+// the returned error joins a LoadError for each broken file.
 func FromDirs(dataType DataType, recursive bool, paths ...string) BundleSource {
 	return func(b *Bundle) error {
+		errs := make([]error, 0, len(paths))
+
 		for _, path := range paths {
-			if err := readFromDirectory(path, dataType, recursive, b.addTranslations); err != nil {
-				return err
-			}
+			refs, err := readFromDirectory(path, dataType, recursive)
+
+			errs = append(errs, err, b.addFileRefs(refs))
 		}
 
-		return nil
+		return errors.Join(errs...)
 	}
 }
 
 // FromFiles reads Translations from the specified files.
 func FromFiles(dataType DataType, paths ...string) BundleSource {
 	return func(b *Bundle) error {
-		for _, path := range paths {
-			lang, translations, err := readFromFile(path, dataType)
-			if err != nil {
-				return err
-			}
+		refs := make([]fileRef, 0, len(paths))
 
-			b.addTranslations(lang, translations)
+		for _, path := range paths {
+			refs = append(refs, fileRef{path: path, dataType: dataType})
 		}
 
-		return nil
+		return b.addFileRefs(refs)
 	}
 }
 
 // FromEmbeddedFS reads Translations from the embed.FS.
 func FromEmbeddedFS(dataType DataType, fs embed.FS, recursive bool, paths ...string) BundleSource {
 	return func(b *Bundle) error {
+		errs := make([]error, 0, len(paths))
+
 		for _, path := range paths {
-			err := readFromEmbeddedDirectory(fs, path, dataType, recursive, b.addTranslations)
-			if err != nil {
-				return err
-			}
+			refs, err := readFromEmbeddedDirectory(fs, path, dataType, recursive)
+
+			errs = append(errs, err, b.addFileRefs(refs))
 		}
 
-		return nil
+		return errors.Join(errs...)
 	}
 }
 
@@ -74,12 +79,12 @@ func FromString(dataType DataType, inp string) BundleSource {
 // FromBytes parses Translations from the specified bytes array.
 func FromBytes(dataType DataType, inp []byte) BundleSource {
 	return func(b *Bundle) error {
-		lang, translations, err := readFromBytes(inp, dataType)
+		file, err := readFromBytes("", inp, dataType)
 		if err != nil {
 			return err
 		}
 
-		b.addTranslations(lang, translations)
+		b.addFile(file)
 
 		return nil
 	}
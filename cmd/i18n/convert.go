@@ -0,0 +1,127 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/kukymbr/i18n"
+	"github.com/kukymbr/i18n/internal/convert"
+)
+
+// runConvert converts the translation files to another format:
+//
+//	i18n convert -from yaml -to xliff -out build/xliff translations
+func runConvert(args []string, stdout io.Writer) error {
+	var (
+		from      string
+		to        string
+		out       string
+		fallback  string
+		recursive bool
+		langs     stringsFlag
+	)
+
+	fs := flag.NewFlagSet("convert", flag.ContinueOnError)
+	fs.StringVar(&from, "from", string(i18n.YAML), "data type of the input files: YAML, JSON or a registered one")
+	fs.StringVar(&to, "to", "", "output format: json, yaml, po, xliff, csv or arb")
+	fs.StringVar(&out, "out", "", "output directory, stdout if empty and there is a single output file")
+	fs.StringVar(&fallback, "fallback", i18n.English.String(), "fallback language, the source language of the XLIFF")
+	fs.BoolVar(&recursive, "recursive", true, "read the directories recursively")
+	fs.Var(&langs, "lang", "languages to convert, all by default")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: i18n convert [flags] <files or directories>")
+		fs.PrintDefaults()
+	}
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if fs.NArg() == 0 {
+		return errors.New("at least one input file or directory is required")
+	}
+
+	fallbackLang, err := i18n.Parse(fallback)
+	if err != nil {
+		return fmt.Errorf("invalid fallback language: %w", err)
+	}
+
+	tags, err := parseLanguages(langs)
+	if err != nil {
+		return err
+	}
+
+	sources, err := getPathsSources(i18n.DataType(strings.ToUpper(from)), recursive, fs.Args())
+	if err != nil {
+		return err
+	}
+
+	bundle, err := i18n.NewBundle(fallbackLang, sources...)
+	if err != nil {
+		return err
+	}
+
+	files, err := convert.Convert(bundle.GetBundleExport(), convert.Format(strings.ToLower(to)), tags...)
+	if err != nil {
+		return err
+	}
+
+	if out == "" {
+		if len(files) != 1 {
+			return fmt.Errorf("-out is required for %d output files", len(files))
+		}
+
+		return writeOutput("", files[0].Data, stdout)
+	}
+
+	for _, file := range files {
+		if err := writeOutput(filepath.Join(out, file.Name), file.Data, stdout); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// getPathsSources returns the sources reading the files and the directories.
+func getPathsSources(dataType i18n.DataType, recursive bool, paths []string) ([]i18n.BundleSource, error) {
+	var dirs, files []string
+
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, err
+		}
+
+		if info.IsDir() {
+			dirs = append(dirs, path)
+		} else {
+			files = append(files, path)
+		}
+	}
+
+	return []i18n.BundleSource{
+		i18n.FromDirs(dataType, recursive, dirs...),
+		i18n.FromFiles(dataType, files...),
+	}, nil
+}
+
+func parseLanguages(langs []string) ([]i18n.Tag, error) {
+	tags := make([]i18n.Tag, 0, len(langs))
+
+	for _, lang := range langs {
+		tag, err := i18n.Parse(lang)
+		if err != nil {
+			return nil, fmt.Errorf("invalid language %s: %w", lang, err)
+		}
+
+		tags = append(tags, tag)
+	}
+
+	return tags, nil
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"slices"
+
+	"github.com/kukymbr/i18n/internal/extract"
+)
+
+// runExtract finds translation keys used in the Go packages:
+//
+//	i18n extract -format pot -out translations/keys.pot ./...
+//
+// With the translation directories set, only the keys missing in the fallback language are listed:
+//
+//	i18n extract -dir translations -format yaml ./...
+func runExtract(args []string, stdout io.Writer) error {
+	var (
+		src    sourceFlags
+		out    string
+		format string
+		tests  bool
+	)
+
+	fs := flag.NewFlagSet("extract", flag.ContinueOnError)
+	src.register(fs)
+	fs.StringVar(&out, "out", "", "output file, stdout if empty")
+	fs.StringVar(&format, "format", string(extract.FormatYAML), "output format: pot, yaml or json")
+	fs.BoolVar(&tests, "tests", false, "extract keys from the _test.go files too")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: i18n extract [flags] [packages]")
+		fs.PrintDefaults()
+	}
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	patterns := fs.Args()
+	if len(patterns) == 0 {
+		patterns = []string{"./..."}
+	}
+
+	keys, err := extract.Extract(patterns, extract.Options{Tests: tests})
+	if err != nil {
+		return err
+	}
+
+	if len(src.dirs) > 0 {
+		if keys, err = filterMissingKeys(&src, keys); err != nil {
+			return err
+		}
+	}
+
+	data, err := extract.Encode(keys, extract.Format(format), src.fallback)
+	if err != nil {
+		return err
+	}
+
+	return writeOutput(out, data, stdout)
+}
+
+// filterMissingKeys returns the keys absent in the fallback language of the translations.
+func filterMissingKeys(src *sourceFlags, keys []extract.Key) ([]extract.Key, error) {
+	bundle, err := src.loadBundle()
+	if err != nil {
+		return nil, err
+	}
+
+	translations := bundle.GetLanguageExport(bundle.GetFallbackLanguage()).Translations
+
+	return slices.DeleteFunc(keys, func(key extract.Key) bool {
+		_, ok := translations[key.Key]
+
+		return ok
+	}), nil
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"flag"
+	"io"
+	"path/filepath"
+
+	"github.com/kukymbr/i18n"
+	"github.com/kukymbr/i18n/internal/codegen"
+)
+
+// modeMessages is the gen mode generating a function per message of the fallback language.
+const modeMessages = "messages"
+
+// runGen generates Go constants of the translation keys or typed message functions:
+//
+//	//go:generate go run github.com/kukymbr/i18n/cmd/i18n gen -dir ../translations -out keys.go
+func runGen(args []string, stdout io.Writer) error {
+	var (
+		src  sourceFlags
+		out  string
+		pkg  string
+		mode string
+	)
+
+	fs := flag.NewFlagSet("gen", flag.ContinueOnError)
+	src.register(fs)
+	fs.StringVar(&out, "out", "", "output file, stdout if empty")
+	fs.StringVar(&pkg, "pkg", "", "package name, the output directory name by default")
+	fs.StringVar(
+		&mode, "mode", string(codegen.KeysConst),
+		"const (keys.GreetingHello), tree (keys.Greeting.Hello) or messages (msgs.GreetingHelloName(tr, name))",
+	)
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	bundle, err := src.loadBundle()
+	if err != nil {
+		return err
+	}
+
+	if pkg == "" {
+		pkg = getDefaultPackage(out, mode)
+	}
+
+	var data []byte
+
+	if mode == modeMessages {
+		data, err = codegen.GenerateMessages(getFallbackMessages(bundle), codegen.MessagesOptions{Package: pkg})
+	} else {
+		data, err = codegen.GenerateKeys(getAllKeys(bundle), codegen.KeysOptions{
+			Package: pkg,
+			Mode:    codegen.KeysMode(mode),
+		})
+	}
+
+	if err != nil {
+		return err
+	}
+
+	return writeOutput(out, data, stdout)
+}
+
+// getDefaultPackage returns the package name from the output file's directory.
+func getDefaultPackage(out string, mode string) string {
+	fallback := "keys"
+	if mode == modeMessages {
+		fallback = "messages"
+	}
+
+	if out == "" {
+		return fallback
+	}
+
+	abs, err := filepath.Abs(out)
+	if err != nil {
+		return fallback
+	}
+
+	if name := codegen.ToPackageName(filepath.Base(filepath.Dir(abs))); name != "" {
+		return name
+	}
+
+	return fallback
+}
+
+// getFallbackMessages returns translations of the bundle's fallback language.
+func getFallbackMessages(bundle *i18n.Bundle) []codegen.Message {
+	translations := bundle.GetLanguageExport(bundle.GetFallbackLanguage()).Translations
+	messages := make([]codegen.Message, 0, len(translations))
+
+	for key, text := range translations {
+		messages = append(messages, codegen.Message{Key: key, Text: text})
+	}
+
+	return messages
+}
@@ -0,0 +1,143 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/kukymbr/i18n"
+	"github.com/kukymbr/i18n/internal/extract"
+)
+
+// runLint reports missing, extra and unused keys of the translations, failing if the completion
+// of any language is below the threshold:
+//
+//	i18n lint -dir translations -code ./... -threshold 95
+func runLint(args []string, stdout io.Writer) error {
+	var (
+		src       sourceFlags
+		code      stringsFlag
+		tests     bool
+		threshold float64
+		format    string
+	)
+
+	fs := flag.NewFlagSet("lint", flag.ContinueOnError)
+	src.register(fs)
+	fs.Var(&code, "code", "Go packages to extract the used keys from (./...), could be repeated or comma-separated")
+	fs.BoolVar(&tests, "tests", false, "extract keys from the _test.go files too")
+	fs.Float64Var(&threshold, "threshold", 0, "minimum completion percentage of every language")
+	fs.StringVar(&format, "format", "text", "report format: text or json")
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	bundle, err := src.loadBundle()
+	if err != nil {
+		return err
+	}
+
+	var usedKeys []string
+
+	if len(code) > 0 {
+		keys, err := extract.Extract(code, extract.Options{Tests: tests})
+		if err != nil {
+			return err
+		}
+
+		for _, key := range keys {
+			usedKeys = append(usedKeys, key.Key)
+		}
+	}
+
+	report := bundle.GetCoverage(usedKeys...)
+
+	switch format {
+	case "text":
+		writeCoverageText(stdout, report)
+	case "json":
+		if err := writeCoverageJSON(stdout, report); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("unknown format %q, expected text or json", format)
+	}
+
+	var failed []string
+
+	for _, lang := range report.Languages {
+		if lang.Completion() < threshold {
+			failed = append(failed, fmt.Sprintf("%s (%.1f%%)", lang.Language, lang.Completion()))
+		}
+	}
+
+	if len(failed) > 0 {
+		return fmt.Errorf("completion is below %g%%: %s", threshold, strings.Join(failed, ", "))
+	}
+
+	return nil
+}
+
+func writeCoverageText(w io.Writer, report i18n.CoverageReport) {
+	for _, lang := range report.Languages {
+		fmt.Fprintf(w, "%s: %.1f%% (%d/%d)\n", lang.Language, lang.Completion(), lang.Translated, lang.Total)
+		writeKeysList(w, "  ", "missing", lang.Missing)
+		writeKeysList(w, "  ", "extra", lang.Extra)
+	}
+
+	writeKeysList(w, "", "unused", report.Unused)
+}
+
+func writeKeysList(w io.Writer, indent string, title string, keys []string) {
+	if len(keys) == 0 {
+		return
+	}
+
+	fmt.Fprintf(w, "%s%s (%d):\n", indent, title, len(keys))
+
+	for _, key := range keys {
+		fmt.Fprintln(w, indent+"  "+key)
+	}
+}
+
+type coverageJSON struct {
+	FallbackLanguage string         `json:"fallback_language"`
+	Languages        []languageJSON `json:"languages"`
+	Unused           []string       `json:"unused,omitempty"`
+}
+
+type languageJSON struct {
+	Language   string   `json:"language"`
+	Completion float64  `json:"completion"`
+	Total      int      `json:"total"`
+	Translated int      `json:"translated"`
+	Missing    []string `json:"missing,omitempty"`
+	Extra      []string `json:"extra,omitempty"`
+}
+
+func writeCoverageJSON(w io.Writer, report i18n.CoverageReport) error {
+	data := coverageJSON{
+		FallbackLanguage: report.FallbackLanguage.String(),
+		Languages:        make([]languageJSON, 0, len(report.Languages)),
+		Unused:           report.Unused,
+	}
+
+	for _, lang := range report.Languages {
+		data.Languages = append(data.Languages, languageJSON{
+			Language:   lang.Language.String(),
+			Completion: lang.Completion(),
+			Total:      lang.Total,
+			Translated: lang.Translated,
+			Missing:    lang.Missing,
+			Extra:      lang.Extra,
+		})
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+
+	return enc.Encode(data)
+}
@@ -0,0 +1,70 @@
+// Command i18n is a toolkit for the translation files of the github.com/kukymbr/i18n bundles.
+//
+// Usage:
+//
+//	i18n <command> [flags]
+//
+// Run `i18n <command> -h` for the command flags.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+)
+
+type command struct {
+	name  string
+	usage string
+	run   func(args []string, stdout io.Writer) error
+}
+
+func getCommands() []command {
+	return []command{
+		{name: "gen", usage: "generate Go constants of the translation keys", run: runGen},
+		{name: "extract", usage: "find translation keys used in the Go source code", run: runExtract},
+		{name: "lint", usage: "report missing, extra and unused translation keys", run: runLint},
+		{name: "sync", usage: "add missing and remove stale keys of the translation files", run: runSync},
+		{name: "convert", usage: "convert the translation files to json, yaml, po, xliff, csv or arb", run: runConvert},
+	}
+}
+
+func main() {
+	if err := run(os.Args[1:], os.Stdout); err != nil {
+		if !errors.Is(err, flag.ErrHelp) {
+			fmt.Fprintln(os.Stderr, "i18n:", err)
+		}
+
+		os.Exit(1)
+	}
+}
+
+func run(args []string, stdout io.Writer) error {
+	if len(args) == 0 {
+		printUsage(os.Stderr)
+
+		return errors.New("command is required")
+	}
+
+	for _, cmd := range getCommands() {
+		if cmd.name == args[0] {
+			return cmd.run(args[1:], stdout)
+		}
+	}
+
+	printUsage(os.Stderr)
+
+	return fmt.Errorf("unknown command %q", args[0])
+}
+
+func printUsage(w io.Writer) {
+	fmt.Fprintln(w, "Usage: i18n <command> [flags]")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Commands:")
+
+	for _, cmd := range getCommands() {
+		fmt.Fprintf(w, "  %-10s %s\n", cmd.name, cmd.usage)
+	}
+}
@@ -0,0 +1,195 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRun(t *testing.T) {
+	require.Error(t, run(nil, &bytes.Buffer{}))
+	require.Error(t, run([]string{"unknown"}, &bytes.Buffer{}))
+}
+
+func TestRunGen(t *testing.T) {
+	t.Run("to stdout", func(t *testing.T) {
+		var out bytes.Buffer
+
+		require.NoError(t, run([]string{"gen", "-dir", "../../testdata/example"}, &out))
+
+		assert.Contains(t, out.String(), "package keys")
+		assert.Contains(t, out.String(), `GreetingHelloName = "greeting.hello_name"`)
+	})
+
+	t.Run("to file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "messages", "keys_gen.go")
+
+		err := run([]string{"gen", "-dir", "../../testdata/example", "-mode", "tree", "-out", path}, &bytes.Buffer{})
+		require.NoError(t, err)
+
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+
+		assert.Contains(t, string(data), "package messages")
+		assert.Contains(t, string(data), "var Greeting = greetingKeys{")
+	})
+
+	t.Run("without dirs", func(t *testing.T) {
+		require.Error(t, run([]string{"gen"}, &bytes.Buffer{}))
+	})
+}
+
+func TestRunGen_Messages(t *testing.T) {
+	var out bytes.Buffer
+
+	require.NoError(t, run([]string{"gen", "-dir", "../../testdata/example", "-mode", "messages"}, &out))
+
+	assert.Contains(t, out.String(), "package messages")
+	assert.Contains(t, out.String(), "func GreetingHelloName(tr i18n.Translator, name string) string {")
+}
+
+func TestRunExtract(t *testing.T) {
+	const sources = "../../internal/extract/testdata/app/..."
+
+	t.Run("all keys", func(t *testing.T) {
+		var out bytes.Buffer
+
+		require.NoError(t, run([]string{"extract", "-format", "json", sources}, &out))
+
+		assert.Contains(t, out.String(), `"key": "greeting.hello"`)
+		assert.Contains(t, out.String(), `"key": "errors.not_found"`)
+	})
+
+	t.Run("missing keys", func(t *testing.T) {
+		var out bytes.Buffer
+
+		require.NoError(t, run([]string{"extract", "-dir", "../../testdata/example", "-format", "pot", sources}, &out))
+
+		assert.NotContains(t, out.String(), `msgid "greeting.hello"`)
+		assert.Contains(t, out.String(), `msgid "errors.not_found"`)
+	})
+
+	t.Run("unknown format", func(t *testing.T) {
+		require.Error(t, run([]string{"extract", "-format", "xml", sources}, &bytes.Buffer{}))
+	})
+}
+
+func TestRunLint(t *testing.T) {
+	t.Run("text", func(t *testing.T) {
+		var out bytes.Buffer
+
+		require.NoError(t, run([]string{"lint", "-dir", "../../testdata/example", "-threshold", "100"}, &out))
+
+		assert.Contains(t, out.String(), "en: 100.0% (3/3)\n")
+		assert.Contains(t, out.String(), "es: 100.0% (3/3)\n")
+	})
+
+	t.Run("with code below threshold", func(t *testing.T) {
+		var out bytes.Buffer
+
+		err := run([]string{
+			"lint", "-dir", "../../testdata/example", "-code", "../../internal/extract/testdata/app/...",
+			"-format", "json", "-threshold", "50",
+		}, &out)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "completion is below 50%: en (37.5%), es (37.5%)")
+
+		assert.Contains(t, out.String(), `"fallback_language": "en"`)
+		assert.Contains(t, out.String(), `"errors.not_found"`)
+	})
+
+	t.Run("unknown format", func(t *testing.T) {
+		require.Error(t, run([]string{"lint", "-dir", "../../testdata/example", "-format", "xml"}, &bytes.Buffer{}))
+	})
+}
+
+func TestRunSync(t *testing.T) {
+	dir := t.TempDir()
+
+	writeFile(t, filepath.Join(dir, "common.en.yaml"), "language: en\ntranslations:\n  hello: Hello!\n  bye: Bye!\n")
+	writeFile(t, filepath.Join(dir, "common.es.yaml"), "language: es\ntranslations:\n  hello: ¡Hola! # greeting\n  old: Viejo\n")
+	writeFile(t, filepath.Join(dir, "nested", "en", "menu.json"), `{"language": "en", "translations": {"home": "Home"}}`)
+
+	t.Run("dry run", func(t *testing.T) {
+		var out bytes.Buffer
+
+		require.NoError(t, run([]string{"sync", "-dir", dir, "-dry-run"}, &out))
+
+		assert.Equal(t, filepath.Join(dir, "common.es.yaml")+": +1 -1\n"+
+			filepath.Join(dir, "nested", "es", "menu.json")+": +1 -0\n", out.String())
+		assert.NoFileExists(t, filepath.Join(dir, "nested", "es", "menu.json"))
+	})
+
+	t.Run("write", func(t *testing.T) {
+		require.NoError(t, run([]string{"sync", "-dir", dir, "-lang", "es,de", "-fill", "empty"}, &bytes.Buffer{}))
+
+		data, err := os.ReadFile(filepath.Join(dir, "common.es.yaml"))
+		require.NoError(t, err)
+		assert.Equal(t, "language: es\ntranslations:\n  hello: ¡Hola! # greeting\n  bye: \"\" # TODO: translate\n", string(data))
+
+		data, err = os.ReadFile(filepath.Join(dir, "nested", "de", "menu.json"))
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"language": "de", "translations": {"home": ""}}`, string(data))
+
+		var out bytes.Buffer
+
+		require.NoError(t, run([]string{"sync", "-dir", dir, "-lang", "es,de"}, &out))
+		assert.Empty(t, out.String())
+	})
+
+	t.Run("when directory path has language", func(t *testing.T) {
+		root := filepath.Join(t.TempDir(), "en", "translations")
+
+		writeFile(t, filepath.Join(root, "en", "menu.json"), `{"language": "en", "translations": {"home": "Home"}}`)
+
+		require.NoError(t, run([]string{"sync", "-dir", root, "-lang", "es"}, &bytes.Buffer{}))
+		assert.FileExists(t, filepath.Join(root, "es", "menu.json"))
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		require.Error(t, run([]string{"sync"}, &bytes.Buffer{}))
+		require.Error(t, run([]string{"sync", "-dir", dir, "-fill", "unknown"}, &bytes.Buffer{}))
+		require.Error(t, run([]string{"sync", "-dir", dir, "-source", "fr"}, &bytes.Buffer{}))
+	})
+}
+
+func writeFile(t *testing.T, path string, content string) {
+	t.Helper()
+
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+}
+
+func TestRunConvert(t *testing.T) {
+	t.Run("to directory", func(t *testing.T) {
+		dir := t.TempDir()
+
+		require.NoError(t, run([]string{"convert", "-to", "po", "-out", dir, "../../testdata/example"}, &bytes.Buffer{}))
+
+		data, err := os.ReadFile(filepath.Join(dir, "es.po"))
+		require.NoError(t, err)
+		assert.Contains(t, string(data), "msgid \"greeting.hello\"\nmsgstr \"¡Hola!\"\n")
+		assert.FileExists(t, filepath.Join(dir, "en.po"))
+	})
+
+	t.Run("to stdout", func(t *testing.T) {
+		var out bytes.Buffer
+
+		err := run([]string{
+			"convert", "-from", "json", "-to", "arb", "-lang", "es", "../../testdata/json/es/es.json",
+		}, &out)
+		require.NoError(t, err)
+		assert.Contains(t, out.String(), `"@@locale": "es"`)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		require.Error(t, run([]string{"convert", "-to", "po"}, &bytes.Buffer{}))
+		require.Error(t, run([]string{"convert", "-to", "po", "../../testdata/example"}, &bytes.Buffer{}))
+		require.Error(t, run([]string{"convert", "-to", "xml", "-out", t.TempDir(), "../../testdata/example"}, &bytes.Buffer{}))
+		require.Error(t, run([]string{"convert", "-to", "po", "../../testdata/missing"}, &bytes.Buffer{}))
+	})
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/kukymbr/i18n"
+)
+
+// sourceFlags are the flags of the translation files, loaded same as the i18n.FromDirs does.
+type sourceFlags struct {
+	dirs      stringsFlag
+	dataType  string
+	fallback  string
+	recursive bool
+}
+
+func (f *sourceFlags) register(fs *flag.FlagSet) {
+	fs.Var(&f.dirs, "dir", "directory with the translation files, could be repeated or comma-separated")
+	fs.StringVar(&f.dataType, "type", string(i18n.YAML), "data type of the translation files: YAML or JSON")
+	fs.StringVar(&f.fallback, "fallback", i18n.English.String(), "fallback language")
+	fs.BoolVar(&f.recursive, "recursive", true, "read the directories recursively")
+}
+
+func (f *sourceFlags) loadBundle() (*i18n.Bundle, error) {
+	if len(f.dirs) == 0 {
+		return nil, errors.New("at least one -dir is required")
+	}
+
+	fallback, err := i18n.Parse(f.fallback)
+	if err != nil {
+		return nil, fmt.Errorf("invalid fallback language: %w", err)
+	}
+
+	dataType := i18n.DataType(strings.ToUpper(f.dataType))
+
+	return i18n.NewBundle(fallback, i18n.FromDirs(dataType, f.recursive, f.dirs...))
+}
+
+// getAllKeys returns sorted keys of all the bundle's languages.
+func getAllKeys(bundle *i18n.Bundle) []string {
+	keys := make(map[string]struct{})
+
+	for _, lang := range bundle.GetBundleExport().Languages {
+		for key := range lang.Translations {
+			keys[key] = struct{}{}
+		}
+	}
+
+	return slices.Sorted(maps.Keys(keys))
+}
+
+// writeOutput writes the data to the file or to the stdout if the path is empty.
+func writeOutput(path string, data []byte, stdout io.Writer) error {
+	if path == "" {
+		_, err := stdout.Write(data)
+
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	//nolint:gosec // generated files are readable by everyone
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write output: %w", err)
+	}
+
+	return nil
+}
+
+// stringsFlag is a repeated flag with comma-separated values.
+type stringsFlag []string
+
+func (f *stringsFlag) String() string {
+	return strings.Join(*f, ",")
+}
+
+func (f *stringsFlag) Set(value string) error {
+	for _, v := range strings.Split(value, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			*f = append(*f, v)
+		}
+	}
+
+	return nil
+}
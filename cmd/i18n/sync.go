@@ -0,0 +1,195 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/kukymbr/i18n/internal/filesync"
+)
+
+// runSync creates or updates the translation files of the target languages
+// to have the same keys as the source language's files:
+//
+//	i18n sync -dir translations -source en -lang es,de
+func runSync(args []string, stdout io.Writer) error {
+	var (
+		dirs      stringsFlag
+		langs     stringsFlag
+		source    string
+		recursive bool
+		fill      string
+		marker    string
+		keepStale bool
+		dryRun    bool
+	)
+
+	fs := flag.NewFlagSet("sync", flag.ContinueOnError)
+	fs.Var(&dirs, "dir", "directory with the translation files, could be repeated or comma-separated")
+	fs.StringVar(&source, "source", "en", "source language")
+	fs.Var(&langs, "lang", "target languages, the languages of the existing files by default")
+	fs.BoolVar(&recursive, "recursive", true, "read the directories recursively")
+	fs.StringVar(&fill, "fill", "", "text of the added keys: source or empty (source for YAML and empty for JSON files by default)")
+	fs.StringVar(&marker, "marker", "TODO: translate", "comment of the added keys in the YAML files")
+	fs.BoolVar(&keepStale, "keep-stale", false, "keep the keys absent in the source language")
+	fs.BoolVar(&dryRun, "dry-run", false, "report the changes without writing the files")
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if len(dirs) == 0 {
+		return errors.New("at least one -dir is required")
+	}
+
+	if fill != "" && fill != string(filesync.FillSource) && fill != string(filesync.FillEmpty) {
+		return fmt.Errorf("unknown fill mode %q, expected source or empty", fill)
+	}
+
+	files, err := findTranslationFiles(dirs, recursive)
+	if err != nil {
+		return err
+	}
+
+	sources, others := splitSourceFiles(files, source)
+	if len(sources) == 0 {
+		return fmt.Errorf("no files of the source language %s found", source)
+	}
+
+	if len(langs) == 0 {
+		langs = others
+	}
+
+	opts := filesync.Options{Fill: filesync.FillMode(fill), Marker: marker, KeepStale: keepStale}
+
+	for _, file := range sources {
+		for _, lang := range langs {
+			if err := syncFile(file, source, lang, opts, dryRun, stdout); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+type translationFile struct {
+	path string
+	lang string
+	// root is the directory the file is found in.
+	root string
+}
+
+// findTranslationFiles returns YAML and JSON files of the directories with their languages.
+func findTranslationFiles(dirs []string, recursive bool) ([]translationFile, error) {
+	var files []translationFile
+
+	for _, dir := range dirs {
+		err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+
+			if d.IsDir() {
+				if path != dir && (!recursive || strings.HasPrefix(d.Name(), ".")) {
+					return filepath.SkipDir
+				}
+
+				return nil
+			}
+
+			if !isTranslationFile(d.Name()) {
+				return nil
+			}
+
+			data, err := os.ReadFile(path)
+			if err != nil {
+				return err
+			}
+
+			lang, err := filesync.ReadLanguage(data)
+			if err != nil {
+				return fmt.Errorf("%s: %w", path, err)
+			}
+
+			files = append(files, translationFile{path: path, lang: lang, root: dir})
+
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", dir, err)
+		}
+	}
+
+	return files, nil
+}
+
+func isTranslationFile(name string) bool {
+	if strings.HasPrefix(name, ".") {
+		return false
+	}
+
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".yaml", ".yml", ".json":
+		return true
+	default:
+		return false
+	}
+}
+
+// splitSourceFiles returns the source language files and the sorted languages of the other ones.
+func splitSourceFiles(files []translationFile, source string) ([]translationFile, []string) {
+	var (
+		sources []translationFile
+		langs   []string
+	)
+
+	for _, file := range files {
+		if strings.EqualFold(file.lang, source) {
+			sources = append(sources, file)
+		} else if !slices.Contains(langs, file.lang) {
+			langs = append(langs, file.lang)
+		}
+	}
+
+	slices.Sort(langs)
+
+	return sources, langs
+}
+
+func syncFile(file translationFile, source string, lang string, opts filesync.Options, dryRun bool, stdout io.Writer) error {
+	srcData, err := os.ReadFile(file.path)
+	if err != nil {
+		return fmt.Errorf("failed to read source file: %w", err)
+	}
+
+	target := filesync.GetTargetPath(file.root, file.path, source, lang)
+
+	dstData, err := os.ReadFile(target)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to read target file: %w", err)
+	}
+
+	result, err := filesync.Sync(target, srcData, dstData, lang, opts)
+	if err != nil {
+		return fmt.Errorf("%s: %w", file.path, err)
+	}
+
+	if len(result.Added) == 0 && len(result.Removed) == 0 && dstData != nil {
+		return nil
+	}
+
+	fmt.Fprintf(stdout, "%s: +%d -%d\n", target, len(result.Added), len(result.Removed))
+
+	if dryRun {
+		return nil
+	}
+
+	return writeOutput(target, result.Data, stdout)
+}
@@ -0,0 +1,100 @@
+package i18n
+
+import (
+	"strings"
+)
+
+// CoverageReport is a result of the Bundle.GetCoverage.
+type CoverageReport struct {
+	FallbackLanguage Tag
+	// Languages are the coverages of all the bundle's languages including the fallback one, sorted by the language.
+	Languages []LanguageCoverage
+	// Unused are the keys of the translations not used in the source code.
+	// Set only if the used keys are passed to the Bundle.GetCoverage.
+	Unused []string
+}
+
+// LanguageCoverage describes the completeness of the language's translations.
+type LanguageCoverage struct {
+	Language Tag
+	// Total is a number of the expected keys: the keys of the fallback language and the used keys.
+	Total int
+	// Translated is a number of the expected keys translated to the language.
+	Translated int
+	// Missing are the expected keys absent in the language.
+	Missing []string
+	// Extra are the keys of the language absent in the fallback language.
+	Extra []string
+}
+
+// Completion returns the percentage of the translated keys, 100 if there are no expected keys.
+func (c LanguageCoverage) Completion() float64 {
+	if c.Total == 0 {
+		return 100
+	}
+
+	return float64(c.Translated) / float64(c.Total) * 100
+}
+
+// GetCoverage compares the translations of each language with the ones of the fallback language.
+// If the used keys are passed (for example, extracted from the source code with the `i18n extract`),
+// they are expected in all the languages too, and the keys not used are reported as unused.
+// Note that all the registered lazy files are loaded by the check.
+func (b *Bundle) GetCoverage(usedKeys ...string) CoverageReport {
+	b.ensureAllLanguages()
+
+	report := CoverageReport{FallbackLanguage: b.fallbackLanguage}
+
+	base, _ := b.getLanguageTranslations(b.fallbackLanguage)
+
+	expected := make(map[string]struct{}, len(base)+len(usedKeys))
+	for key := range base {
+		expected[key] = struct{}{}
+	}
+
+	for _, key := range usedKeys {
+		expected[key] = struct{}{}
+	}
+
+	expectedKeys := getSortedKeys(expected, strings.Compare)
+	translated := make(map[string]struct{}, len(base))
+
+	for _, lang := range b.getLanguages() {
+		translations, _ := b.getLanguageTranslations(lang)
+
+		coverage := LanguageCoverage{Language: lang, Total: len(expectedKeys)}
+
+		for _, key := range expectedKeys {
+			if _, ok := translations[key]; ok {
+				coverage.Translated++
+			} else {
+				coverage.Missing = append(coverage.Missing, key)
+			}
+		}
+
+		for _, key := range getSortedKeys(translations, strings.Compare) {
+			translated[key] = struct{}{}
+
+			if _, ok := base[key]; !ok && lang != b.fallbackLanguage {
+				coverage.Extra = append(coverage.Extra, key)
+			}
+		}
+
+		report.Languages = append(report.Languages, coverage)
+	}
+
+	if len(usedKeys) > 0 {
+		used := make(map[string]struct{}, len(usedKeys))
+		for _, key := range usedKeys {
+			used[key] = struct{}{}
+		}
+
+		for _, key := range getSortedKeys(translated, strings.Compare) {
+			if _, ok := used[key]; !ok {
+				report.Unused = append(report.Unused, key)
+			}
+		}
+	}
+
+	return report
+}
@@ -0,0 +1,80 @@
+package i18n_test
+
+import (
+	"testing"
+
+	"github.com/kukymbr/i18n"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBundle_GetCoverage(t *testing.T) {
+	bundle, err := i18n.NewBundle(
+		i18n.English,
+		i18n.FromString(i18n.YAML, `
+language: en
+translations:
+  hello: "Hello!"
+  bye: "Bye!"
+  legacy: "Old text"
+  menu:
+    home: "Home"
+`),
+		i18n.FromString(i18n.YAML, `
+language: es
+translations:
+  hello: "¡Hola!"
+  menu:
+    home: "Inicio"
+    spanish_only: "Sólo"
+`),
+		i18n.FromString(i18n.YAML, `
+language: de
+translations:
+  hello: "Hallo!"
+  bye: "Tschüss!"
+  legacy: "Alter Text"
+  menu:
+    home: "Startseite"
+`),
+	)
+	require.NoError(t, err)
+
+	t.Run("without used keys", func(t *testing.T) {
+		report := bundle.GetCoverage()
+
+		assert.Equal(t, i18n.English, report.FallbackLanguage)
+		assert.Equal(t, []i18n.LanguageCoverage{
+			{Language: i18n.German, Total: 4, Translated: 4},
+			{Language: i18n.English, Total: 4, Translated: 4},
+			{
+				Language:   i18n.Spanish,
+				Total:      4,
+				Translated: 2,
+				Missing:    []string{"bye", "legacy"},
+				Extra:      []string{"menu.spanish_only"},
+			},
+		}, report.Languages)
+		assert.Nil(t, report.Unused)
+
+		assert.InDelta(t, 50.0, report.Languages[2].Completion(), 0.001)
+		assert.InDelta(t, 100.0, report.Languages[0].Completion(), 0.001)
+	})
+
+	t.Run("with used keys", func(t *testing.T) {
+		report := bundle.GetCoverage("hello", "bye", "menu.home", "menu.settings")
+
+		assert.Equal(t, []string{"menu.settings"}, report.Languages[0].Missing)
+		assert.Equal(t, []string{"menu.settings"}, report.Languages[1].Missing)
+		assert.Equal(t, []string{"bye", "legacy", "menu.settings"}, report.Languages[2].Missing)
+		assert.Equal(t, 5, report.Languages[1].Total)
+		assert.Equal(t, []string{"legacy", "menu.spanish_only"}, report.Unused)
+	})
+
+	t.Run("empty bundle", func(t *testing.T) {
+		report := i18n.NewEmptyBundle().GetCoverage()
+
+		assert.Empty(t, report.Languages)
+		assert.InDelta(t, 100.0, i18n.LanguageCoverage{}.Completion(), 0.001)
+	})
+}
@@ -0,0 +1,102 @@
+package i18n
+
+import (
+	"errors"
+	"strings"
+)
+
+// DuplicatePolicy defines how to handle the translation keys defined more than once in the same layer.
+type DuplicatePolicy int
+
+// Available duplicate policies.
+const (
+	// DuplicatesLastWins silently keeps the last loaded translation (default).
+	DuplicatesLastWins DuplicatePolicy = iota
+	// DuplicatesFirstWins silently keeps the first loaded translation,
+	// except for the key repeated in a single JSON object, see the WithDuplicatePolicy.
+	DuplicatesFirstWins
+	// DuplicatesWarn keeps the last loaded translation and passes
+	// every duplicate to the bundle's error handler (see WithErrorHandler).
+	DuplicatesWarn
+	// DuplicatesError fails the bundle loading, reporting all found duplicates.
+	DuplicatesError
+)
+
+// DuplicateKeyError describes a translation key defined more than once in the same layer.
+type DuplicateKeyError struct {
+	Language    Tag
+	Key         string
+	Layer       string
+	Definitions []Position
+}
+
+func (e *DuplicateKeyError) Error() string {
+	positions := make([]string, 0, len(e.Definitions))
+	for _, pos := range e.Definitions {
+		positions = append(positions, pos.String())
+	}
+
+	return "duplicate key " + e.Key + " (language '" + e.Language.String() + "', layer " + e.Layer + "): " +
+		"defined at " + strings.Join(positions, ", ")
+}
+
+// GetDuplicates returns all the duplicate keys found while loading translations.
+// Duplicates are collected regardless of the DuplicatePolicy.
+func (b *Bundle) GetDuplicates() []*DuplicateKeyError {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	return append([]*DuplicateKeyError(nil), b.duplicates...)
+}
+
+type duplicateKey struct {
+	layer string
+	lang  Tag
+	key   string
+}
+
+func (b *Bundle) reportDuplicate(l *layer, lang Tag, key string, positions ...Position) {
+	id := duplicateKey{layer: l.name, lang: lang, key: key}
+
+	dup, ok := b.duplicatesIdx[id]
+	if !ok {
+		dup = &DuplicateKeyError{Language: lang, Key: key, Layer: l.name}
+
+		b.duplicatesIdx[id] = dup
+		b.duplicates = append(b.duplicates, dup)
+	}
+
+	for _, pos := range positions {
+		if !containsPosition(dup.Definitions, pos) {
+			dup.Definitions = append(dup.Definitions, pos)
+		}
+	}
+
+	if b.cfg.duplicatePolicy == DuplicatesWarn {
+		b.warnings = append(b.warnings, dup)
+	}
+}
+
+// checkDuplicates returns the joined duplicate errors if the DuplicatesError policy is set.
+func (b *Bundle) checkDuplicates(duplicates []*DuplicateKeyError) error {
+	if b.cfg.duplicatePolicy != DuplicatesError || len(duplicates) == 0 {
+		return nil
+	}
+
+	errs := make([]error, 0, len(duplicates))
+	for _, dup := range duplicates {
+		errs = append(errs, dup)
+	}
+
+	return errors.Join(errs...)
+}
+
+func containsPosition(positions []Position, pos Position) bool {
+	for _, p := range positions {
+		if p == pos && pos.isKnown() {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,91 @@
+package i18n_test
+
+import (
+	"testing"
+
+	"github.com/kukymbr/i18n"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBundle_DuplicatePolicy(t *testing.T) {
+	sources := []i18n.BundleSource{
+		i18n.FromFiles(i18n.YAML, "testdata/duplicates/first.en.yaml", "testdata/duplicates/second.en.yaml"),
+		i18n.FromFiles(i18n.JSON, "testdata/duplicates/repeated.en.json"),
+	}
+
+	tests := []struct {
+		Name     string
+		Policy   i18n.DuplicatePolicy
+		Greeting string
+		Title    string
+		Warnings int
+	}{
+		{
+			Name:     "last wins",
+			Policy:   i18n.DuplicatesLastWins,
+			Greeting: "Hello from the nested key",
+			Title:    "Another title",
+		},
+		{
+			Name:     "first wins",
+			Policy:   i18n.DuplicatesFirstWins,
+			Greeting: "Hello from the flat key",
+			Title:    "Title",
+		},
+		{
+			Name:     "warn",
+			Policy:   i18n.DuplicatesWarn,
+			Greeting: "Hello from the nested key",
+			Title:    "Another title",
+			Warnings: 3,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			var warnings []error
+
+			bundle, err := i18n.NewBundle(i18n.English, append([]i18n.BundleSource{
+				i18n.WithDuplicatePolicy(test.Policy),
+				i18n.WithErrorHandler(func(err error) {
+					warnings = append(warnings, err)
+				}),
+			}, sources...)...)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.Greeting, bundle.T(i18n.English, "greeting.hello"))
+			assert.Equal(t, test.Title, bundle.T(i18n.English, "title"))
+			assert.Len(t, warnings, test.Warnings)
+			assert.Len(t, bundle.GetDuplicates(), 3)
+		})
+	}
+}
+
+func TestBundle_DuplicatesError(t *testing.T) {
+	_, err := i18n.NewBundle(
+		i18n.English,
+		i18n.WithDuplicatePolicy(i18n.DuplicatesError),
+		i18n.FromFiles(i18n.YAML, "testdata/duplicates/first.en.yaml", "testdata/duplicates/second.en.yaml"),
+		i18n.FromFiles(i18n.JSON, "testdata/duplicates/repeated.en.json"),
+	)
+	require.Error(t, err)
+
+	var dup *i18n.DuplicateKeyError
+
+	require.ErrorAs(t, err, &dup)
+	assert.Equal(t, "greeting.hello", dup.Key)
+	assert.Equal(t, i18n.DefaultLayer, dup.Layer)
+	assert.Equal(t, []i18n.Position{
+		{Path: "testdata/duplicates/first.en.yaml", Line: 3, Column: 3},
+		{Path: "testdata/duplicates/first.en.yaml", Line: 5, Column: 5},
+	}, dup.Definitions)
+
+	assert.ErrorContains(t, err, "duplicate key title (language 'en', layer default): "+
+		"defined at testdata/duplicates/first.en.yaml:6:3, testdata/duplicates/second.en.yaml:3:3")
+	assert.ErrorContains(t, err, "duplicate key subtitle (language 'en', layer default): "+
+		"defined at testdata/duplicates/repeated.en.json:4:5, testdata/duplicates/repeated.en.json:5:5")
+
+	assert.Len(t, i18n.GetDuplicateErrors(err), 3)
+	assert.Empty(t, i18n.GetLoadErrors(err))
+}
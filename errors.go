@@ -0,0 +1,176 @@
+package i18n
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"regexp"
+	"strconv"
+)
+
+// LoadError is an error of loading translations from the source.
+// Line and Column are set when the position of the problem is known;
+// YAML syntax errors have only the Line, as the parser does not report the column.
+type LoadError struct {
+	Path   string
+	Line   int
+	Column int
+	Key    string
+	Err    error
+
+	raw string
+}
+
+func (e *LoadError) Error() string {
+	msg := e.Position().String() + ": "
+
+	if e.Key != "" {
+		msg += "key " + e.Key + ": "
+	}
+
+	return msg + e.Err.Error()
+}
+
+func (e *LoadError) Unwrap() error {
+	return e.Err
+}
+
+// Position returns the Position of the error in the source.
+func (e *LoadError) Position() Position {
+	return Position{Path: e.Path, Line: e.Line, Column: e.Column}
+}
+
+// TemplateError is an error of compiling or executing the template of the translation.
+// Position of the translation is set by the Bundle.Validate when it is known.
+type TemplateError struct {
+	Language Tag
+	Key      string
+	Position Position
+	Err      error
+}
+
+func (e *TemplateError) Error() string {
+	msg := "template of key " + e.Key + " (language '" + e.Language.String() + "'): " + e.Err.Error()
+
+	if e.Position.isKnown() {
+		return e.Position.String() + ": " + msg
+	}
+
+	return msg
+}
+
+func (e *TemplateError) Unwrap() error {
+	return e.Err
+}
+
+// GetLoadErrors returns all the LoadError instances from the error tree,
+// for example, from the error returned by the NewBundle.
+func GetLoadErrors(err error) []*LoadError {
+	return collectErrors[*LoadError](err)
+}
+
+// GetTemplateErrors returns all the TemplateError instances from the error tree,
+// for example, from the error returned by the Bundle.Validate.
+func GetTemplateErrors(err error) []*TemplateError {
+	return collectErrors[*TemplateError](err)
+}
+
+// GetDuplicateErrors returns all the DuplicateKeyError instances from the error tree,
+// for example, from the error returned by the NewBundle with the DuplicatesError policy.
+func GetDuplicateErrors(err error) []*DuplicateKeyError {
+	return collectErrors[*DuplicateKeyError](err)
+}
+
+// collectErrors returns all the errors of the type T from the error tree.
+func collectErrors[T error](err error) []T {
+	var found []T
+
+	//nolint:errorlint // walking the errors tree manually to keep all the errors
+	switch e := err.(type) {
+	case T:
+		return []T{e}
+	case interface{ Unwrap() []error }:
+		for _, inner := range e.Unwrap() {
+			found = append(found, collectErrors[T](inner)...)
+		}
+
+		return found
+	}
+
+	if wrapped := errors.Unwrap(err); wrapped != nil {
+		return collectErrors[T](wrapped)
+	}
+
+	return nil
+}
+
+var rxYAMLErrorLine = regexp.MustCompile(`line (\d+)`)
+
+// newLoadError creates a LoadError, trying to find the error position in the data.
+func newLoadError(path string, data []byte, err error) *LoadError {
+	loadErr := &LoadError{Path: path, Err: err}
+
+	var (
+		syntaxErr *json.SyntaxError
+		typeErr   *json.UnmarshalTypeError
+	)
+
+	switch {
+	case errors.As(err, &syntaxErr):
+		loadErr.setPosition(offsetToPosition(data, int(syntaxErr.Offset)))
+	case errors.As(err, &typeErr):
+		loadErr.setPosition(offsetToPosition(data, int(typeErr.Offset)))
+	default:
+		if offset, ok := getJSONIterErrorOffset(data, err); ok {
+			loadErr.setPosition(offsetToPosition(data, offset))
+
+			break
+		}
+
+		if m := rxYAMLErrorLine.FindStringSubmatch(err.Error()); m != nil {
+			loadErr.Line, _ = strconv.Atoi(m[1])
+		}
+	}
+
+	return loadErr
+}
+
+func (e *LoadError) setPosition(pos Position) {
+	e.Line = pos.Line
+	e.Column = pos.Column
+}
+
+// rxJSONIterError matches the json-iterator's error (the jsoniter build tag): the offset is relative
+// to the window of 10 bytes before and after the error, which is followed by the 50 bytes context.
+var rxJSONIterError = regexp.MustCompile(`(?s)error found in #(\d+) byte of \.\.\.\|(.*)\|\.\.\., bigger context \.\.\.\|(.*)\|\.\.\.`)
+
+const jsonIterContext = 50
+
+// getJSONIterErrorOffset returns the offset of the json-iterator's error in the data,
+// found by the windows its message contains.
+func getJSONIterErrorOffset(data []byte, err error) (int, bool) {
+	m := rxJSONIterError.FindStringSubmatch(err.Error())
+	if m == nil {
+		return 0, false
+	}
+
+	inWindow, _ := strconv.Atoi(m[1])
+	window, context := []byte(m[2]), []byte(m[3])
+
+	for start := 0; start < len(data); start++ {
+		i := bytes.Index(data[start:], window)
+		if i < 0 {
+			break
+		}
+
+		start += i
+		offset := start + inWindow
+
+		from, to := max(0, offset-jsonIterContext), min(len(data), offset+jsonIterContext)
+		if bytes.Equal(data[from:to], context) {
+			return offset, true
+		}
+	}
+
+	return 0, false
+}
@@ -0,0 +1,44 @@
+package i18n_test
+
+import (
+	"testing"
+
+	"github.com/kukymbr/i18n"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetLoadErrors(t *testing.T) {
+	_, err := i18n.NewBundle(
+		i18n.English,
+		i18n.FromDirs(i18n.YAML, false, "testdata/broken"),
+		i18n.FromFiles(i18n.JSON, "testdata/broken/syntax.en.json", "testdata/unknown.json"),
+		i18n.FromString(i18n.JSON, `{"translations": {"nested": {"test": 0}}}`),
+	)
+	require.Error(t, err)
+
+	loadErrors := i18n.GetLoadErrors(err)
+	require.Len(t, loadErrors, 5)
+
+	positions := make([]string, 0, len(loadErrors))
+	for _, loadErr := range loadErrors {
+		positions = append(positions, loadErr.Position().String())
+	}
+
+	assert.Equal(t, []string{
+		"testdata/broken/syntax.en.yaml:2",
+		"testdata/broken/type.en.yaml:5:5",
+		"testdata/broken/syntax.en.json:5:4",
+		"testdata/unknown.json",
+		"<input>:1:30",
+	}, positions)
+
+	assert.Equal(t, "greeting.count", loadErrors[1].Key)
+	assert.EqualError(t, loadErrors[1], "testdata/broken/type.en.yaml:5:5: key greeting.count: expected string or map, got int")
+	assert.Equal(t, "nested.test", loadErrors[4].Key)
+}
+
+func TestGetLoadErrors_WhenNoLoadErrors(t *testing.T) {
+	assert.Empty(t, i18n.GetLoadErrors(nil))
+	assert.Empty(t, i18n.GetLoadErrors(assert.AnError))
+}
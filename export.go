@@ -37,7 +37,10 @@ func NewLanguageExport(b *Bundle, language Tag, filters ...TranslationsFilterFun
 
 	etag := FormatLanguageETag(b.CalcHash(), language)
 
-	translations, ok := b.translations[language]
+	release := b.ensureLanguage(language)
+	defer release()
+
+	translations, ok := b.getLanguageTranslations(language, filters...)
 	if !ok {
 		return LanguageExport{
 			ETag:         etag,
@@ -49,7 +52,7 @@ func NewLanguageExport(b *Bundle, language Tag, filters ...TranslationsFilterFun
 	return LanguageExport{
 		ETag:         etag,
 		Language:     language,
-		Translations: FilterTranslations(translations, filters...),
+		Translations: translations,
 	}
 }
 
@@ -59,16 +62,24 @@ func NewBundleExport(b *Bundle, filters ...TranslationsFilterFunc) BundleExport
 		b = NewEmptyBundle()
 	}
 
+	etag := b.CalcHash()
+
+	b.ensureAllLanguages()
+
+	languages := b.getLanguages()
+
 	container := BundleExport{
-		ETag:             b.CalcHash(),
+		ETag:             etag,
 		FallbackLanguage: b.fallbackLanguage,
-		Languages:        make([]LanguageExport, 0, len(b.translations)),
+		Languages:        make([]LanguageExport, 0, len(languages)),
 	}
 
-	for lang, translations := range b.translations {
+	for _, lang := range languages {
+		translations, _ := b.getLanguageTranslations(lang, filters...)
+
 		container.Languages = append(container.Languages, LanguageExport{
 			Language:     lang,
-			Translations: FilterTranslations(translations, filters...),
+			Translations: translations,
 		})
 	}
 
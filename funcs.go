@@ -0,0 +1,250 @@
+package i18n
+
+import (
+	"errors"
+	"fmt"
+	htmltemplate "html/template"
+	"maps"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	texttemplate "text/template"
+	"time"
+
+	"golang.org/x/text/cases"
+	"golang.org/x/text/feature/plural"
+	"golang.org/x/text/message"
+	"golang.org/x/text/number"
+)
+
+// maxNestingDepth is the maximum depth of the translations rendered by the `t` template function,
+// protecting from the infinite recursion when translations refer to each other.
+const maxNestingDepth = 5
+
+// FuncMap is a map of functions available in the translation templates,
+// see the text/template.FuncMap for the requirements to the functions.
+type FuncMap map[string]any
+
+// WithTemplateFuncs registers functions available in the translation templates.
+// Functions with the same names as the built-in ones override them.
+//
+// Built-in functions are bound to the requested language, even if the text falls back to another one:
+//   - upper, lower: change the case of the string using the language rules: {{ upper .Name }};
+//   - number: formats the number: {{ number .Amount }} gives 1,234.5 in English and 1.234,5 in German;
+//   - date: formats the time.Time using the layout: {{ .Date | date "02.01.2006" }};
+//   - plural: selects the CLDR plural form (zero, one, two, few, many or other) for the count:
+//     {{ plural .Count "one=item" "other=items" }};
+//   - t: translates another key in the same language: {{ t "common.app_name" }}, {{ t "greeting.hello_name" . }};
+//   - default: returns the default value if the value is empty: {{ .Name | default "friend" }}.
+func WithTemplateFuncs(funcs FuncMap) BundleSource {
+	return func(b *Bundle) error {
+		if err := checkTemplateFuncs(funcs); err != nil {
+			return err
+		}
+
+		// Copied, so the functions of the derived bundles do not leak into the base one.
+		merged := make(FuncMap, len(b.cfg.funcs)+len(funcs))
+		maps.Copy(merged, b.cfg.funcs)
+		maps.Copy(merged, funcs)
+
+		b.cfg.funcs = merged
+
+		return nil
+	}
+}
+
+// WithLanguageTemplateFuncs registers a function returning the template functions for the language.
+// Functions returned by it override the ones registered with the WithTemplateFuncs and the built-in ones.
+func WithLanguageTemplateFuncs(fn func(lang Tag) FuncMap) BundleSource {
+	return func(b *Bundle) error {
+		b.cfg.langFuncs = append(slices.Clip(b.cfg.langFuncs), fn)
+
+		return nil
+	}
+}
+
+func checkTemplateFuncs(funcs FuncMap) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("invalid template functions: %v", r)
+		}
+	}()
+
+	texttemplate.New("").Funcs(texttemplate.FuncMap(funcs))
+
+	return nil
+}
+
+// getTemplateFuncs returns all the template functions for the translation.
+func (b *Bundle) getTemplateFuncs(lang Tag, params translateParams) FuncMap {
+	funcs := b.getBuiltinFuncs(lang, params)
+
+	maps.Copy(funcs, b.cfg.funcs)
+
+	for _, fn := range b.cfg.langFuncs {
+		maps.Copy(funcs, fn(lang))
+	}
+
+	return funcs
+}
+
+func (b *Bundle) getBuiltinFuncs(lang Tag, params translateParams) FuncMap {
+	upper := cases.Upper(lang.Tag)
+	lower := cases.Lower(lang.Tag)
+	printer := message.NewPrinter(lang.Tag)
+
+	return FuncMap{
+		"upper": upper.String,
+		"lower": lower.String,
+		"number": func(value any) (string, error) {
+			if !isNumber(value) {
+				return "", fmt.Errorf("expected number, got %T", value)
+			}
+
+			return printer.Sprint(number.Decimal(value)), nil
+		},
+		"date": formatDate,
+		"plural": func(count any, forms ...string) (string, error) {
+			return selectPluralForm(lang, count, forms)
+		},
+		"t": func(key string, data ...any) (any, error) {
+			return b.translateNested(lang, key, params, data)
+		},
+		"default": func(def any, value any) any {
+			if isEmptyValue(value) {
+				return def
+			}
+
+			return value
+		},
+	}
+}
+
+// translateNested renders the translation of another key for the `t` template function.
+func (b *Bundle) translateNested(lang Tag, key string, params translateParams, data []any) (any, error) {
+	if params.depth >= maxNestingDepth {
+		return nil, fmt.Errorf("nested translation %s: maximum depth %d exceeded", key, maxNestingDepth)
+	}
+
+	nested := translateParams{engine: params.engine, depth: params.depth + 1}
+	if len(data) > 0 {
+		nested.data = data[0]
+	}
+
+	resolved, k, text, ok := b.lookup(lang, key)
+	if !ok {
+		// The key may come from the template data: it is not rendered and is escaped by the html engine.
+		return key, nil
+	}
+
+	text, err := b.render(lang, resolved, k, text, nested)
+	if err != nil {
+		return nil, err
+	}
+
+	// Nested translation is already escaped by the html engine.
+	if params.engine == HTMLEngine {
+		return htmltemplate.HTML(text), nil //nolint:gosec
+	}
+
+	return text, nil
+}
+
+func formatDate(layout string, value any) (string, error) {
+	switch v := value.(type) {
+	case time.Time:
+		return v.Format(layout), nil
+	case *time.Time:
+		if v == nil {
+			return "", nil
+		}
+
+		return v.Format(layout), nil
+	default:
+		return "", fmt.Errorf("expected time.Time, got %T", value)
+	}
+}
+
+// selectPluralForm returns the text of the count's plural form from the `form=text` list.
+// If the language's form is not listed, the `other` form is used.
+func selectPluralForm(lang Tag, count any, forms []string) (string, error) {
+	i, v, f, err := getPluralOperands(count)
+	if err != nil {
+		return "", err
+	}
+
+	texts := make(map[string]string, len(forms))
+
+	for _, form := range forms {
+		name, text, ok := strings.Cut(form, "=")
+		if !ok {
+			return "", fmt.Errorf("invalid plural form %q, expected form=text", form)
+		}
+
+		texts[name] = text
+	}
+
+	name := pluralFormNames[plural.Cardinal.MatchPlural(lang.Tag, i, v, 0, f, 0)]
+	if text, ok := texts[name]; ok {
+		return text, nil
+	}
+
+	if text, ok := texts["other"]; ok {
+		return text, nil
+	}
+
+	return "", errors.New("plural form 'other' is missing")
+}
+
+var pluralFormNames = map[plural.Form]string{
+	plural.Other: "other",
+	plural.Zero:  "zero",
+	plural.One:   "one",
+	plural.Two:   "two",
+	plural.Few:   "few",
+	plural.Many:  "many",
+}
+
+// getPluralOperands returns the CLDR plural operands of the number:
+// integer digits, number of visible fraction digits and the visible fraction digits.
+func getPluralOperands(count any) (i int, v int, f int, err error) {
+	if !isNumber(count) {
+		return 0, 0, 0, fmt.Errorf("expected number, got %T", count)
+	}
+
+	str := fmt.Sprint(count)
+
+	intPart, fracPart, _ := strings.Cut(strings.TrimPrefix(str, "-"), ".")
+
+	if i, err = strconv.Atoi(intPart); err != nil {
+		return 0, 0, 0, fmt.Errorf("unsupported number %s: %w", str, err)
+	}
+
+	if fracPart != "" {
+		if f, err = strconv.Atoi(fracPart); err != nil {
+			return 0, 0, 0, fmt.Errorf("unsupported number %s: %w", str, err)
+		}
+	}
+
+	return i, len(fracPart), f, nil
+}
+
+func isNumber(value any) bool {
+	switch reflect.ValueOf(value).Kind() { //nolint:exhaustive
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	default:
+		return false
+	}
+}
+
+func isEmptyValue(value any) bool {
+	if value == nil {
+		return true
+	}
+
+	return reflect.ValueOf(value).IsZero()
+}
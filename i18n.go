@@ -31,13 +31,31 @@ func Translate(lang Tag, key string, tplData ...any) string {
 	return GetGlobalBundle().Translate(lang, key, tplData...)
 }
 
+// TranslateE translates key using the global bundle, returning the template error.
+// See Bundle.TranslateE for info.
+func TranslateE(lang Tag, key string, tplData ...any) (string, error) {
+	return GetGlobalBundle().TranslateE(lang, key, tplData...)
+}
+
 // T is a short alias of Translate function.
 func T(lang Tag, key string, tplData ...any) string {
 	return Translate(lang, key, tplData...)
 }
 
+// Tf translates key using the global bundle and formats it with the printf-style arguments.
+// See Bundle.Tf for info.
+func Tf(lang Tag, key string, args ...any) string {
+	return GetGlobalBundle().Tf(lang, key, args...)
+}
+
 // TranslateStruct translates a structure using the global bundle.
 // See Bundle.TranslateStruct for info.
 func TranslateStruct(lang Tag, structure any, tplData ...any) error {
 	return GetGlobalBundle().TranslateStruct(lang, structure, tplData...)
 }
+
+// TranslateSlice translates structures of a slice using the global bundle.
+// See Bundle.TranslateSlice for info.
+func TranslateSlice(lang Tag, items any, tplData ...any) error {
+	return GetGlobalBundle().TranslateSlice(lang, items, tplData...)
+}
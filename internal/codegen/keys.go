@@ -0,0 +1,266 @@
+// Package codegen generates Go code from the translation keys and texts.
+package codegen
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// KeysMode is a mode of the keys generation.
+type KeysMode string
+
+const (
+	// KeysConst generates a constant per key: keys.GreetingHello.
+	KeysConst KeysMode = "const"
+	// KeysTree generates a tree of the keys split by the dots: keys.Greeting.Hello.
+	KeysTree KeysMode = "tree"
+)
+
+// KeysOptions are the options of the GenerateKeys.
+type KeysOptions struct {
+	Package string
+	Mode    KeysMode
+}
+
+const generatedHeader = "// Code generated by i18n gen. DO NOT EDIT.\n\n"
+
+// GenerateKeys returns the formatted Go source with the translation keys.
+// Keys resulting in the same identifiers are reported as errors.
+func GenerateKeys(keys []string, opts KeysOptions) ([]byte, error) {
+	if opts.Package == "" {
+		return nil, fmt.Errorf("package name is required")
+	}
+
+	keys = slices.Clone(keys)
+	slices.Sort(keys)
+	keys = slices.Compact(keys)
+
+	var buf bytes.Buffer
+
+	buf.WriteString(generatedHeader)
+	buf.WriteString("package " + opts.Package + "\n\n")
+
+	var err error
+
+	switch opts.Mode {
+	case KeysConst, "":
+		err = writeConstKeys(&buf, keys)
+	case KeysTree:
+		err = writeTreeKeys(&buf, keys)
+	default:
+		err = fmt.Errorf("unknown keys mode %q", opts.Mode)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return format.Source(buf.Bytes())
+}
+
+func writeConstKeys(buf *bytes.Buffer, keys []string) error {
+	names := make(map[string]string, len(keys))
+
+	buf.WriteString("// Translation keys.\nconst (\n")
+
+	for _, key := range keys {
+		name := ToIdentifier(key)
+
+		if other, ok := names[name]; ok {
+			return fmt.Errorf("keys %q and %q have the same identifier %s", other, key, name)
+		}
+
+		names[name] = key
+
+		buf.WriteString(name + " = " + strconv.Quote(key) + "\n")
+	}
+
+	buf.WriteString(")\n")
+
+	return nil
+}
+
+// keyNode is a node of the keys tree.
+type keyNode struct {
+	name     string
+	path     []string
+	key      string
+	children []*keyNode
+}
+
+func (n *keyNode) child(segment string) *keyNode {
+	for _, c := range n.children {
+		if c.path[len(c.path)-1] == segment {
+			return c
+		}
+	}
+
+	c := &keyNode{name: ToIdentifier(segment), path: append(slices.Clone(n.path), segment)}
+	n.children = append(n.children, c)
+
+	return c
+}
+
+func buildKeysTree(keys []string) (*keyNode, error) {
+	root := &keyNode{}
+
+	for _, key := range keys {
+		node := root
+		for _, segment := range strings.Split(key, ".") {
+			node = node.child(segment)
+		}
+
+		node.key = key
+	}
+
+	return root, checkKeysTree(root)
+}
+
+func checkKeysTree(node *keyNode) error {
+	names := make(map[string]*keyNode, len(node.children))
+
+	for _, c := range node.children {
+		if c.key != "" && len(c.children) > 0 {
+			return fmt.Errorf("key %q is also a group of keys, use the const mode", c.key)
+		}
+
+		if other, ok := names[c.name]; ok {
+			return fmt.Errorf(
+				"%q and %q have the same identifier %s",
+				strings.Join(other.path, "."), strings.Join(c.path, "."), c.name,
+			)
+		}
+
+		names[c.name] = c
+
+		if err := checkKeysTree(c); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func writeTreeKeys(buf *bytes.Buffer, keys []string) error {
+	root, err := buildKeysTree(keys)
+	if err != nil {
+		return err
+	}
+
+	types := make(map[string]*keyNode)
+
+	for _, node := range root.children {
+		if node.key != "" {
+			buf.WriteString("// " + node.name + " is the " + strconv.Quote(node.key) + " translation key.\n")
+			buf.WriteString("const " + node.name + " = " + strconv.Quote(node.key) + "\n\n")
+
+			continue
+		}
+
+		buf.WriteString("// " + node.name + " contains keys of the " + strconv.Quote(node.path[0]) + " group.\n")
+		buf.WriteString("var " + node.name + " = ")
+
+		if err := writeTreeValue(buf, node, types); err != nil {
+			return err
+		}
+
+		buf.WriteString("\n\n")
+	}
+
+	for _, typeName := range slices.Sorted(maps.Keys(types)) {
+		buf.WriteString("type " + typeName + " struct {\n")
+
+		for _, c := range types[typeName].children {
+			if c.key != "" {
+				buf.WriteString(c.name + " string\n")
+			} else {
+				buf.WriteString(c.name + " " + treeTypeName(c) + "\n")
+			}
+		}
+
+		buf.WriteString("}\n\n")
+	}
+
+	return nil
+}
+
+func writeTreeValue(buf *bytes.Buffer, node *keyNode, types map[string]*keyNode) error {
+	typeName := treeTypeName(node)
+
+	if other, ok := types[typeName]; ok && other != node {
+		return fmt.Errorf(
+			"groups %q and %q have the same type name %s",
+			strings.Join(other.path, "."), strings.Join(node.path, "."), typeName,
+		)
+	}
+
+	types[typeName] = node
+
+	buf.WriteString(typeName + "{\n")
+
+	for _, c := range node.children {
+		buf.WriteString(c.name + ": ")
+
+		if c.key != "" {
+			buf.WriteString(strconv.Quote(c.key))
+		} else if err := writeTreeValue(buf, c, types); err != nil {
+			return err
+		}
+
+		buf.WriteString(",\n")
+	}
+
+	buf.WriteString("}")
+
+	return nil
+}
+
+func treeTypeName(node *keyNode) string {
+	runes := []rune(ToIdentifier(strings.Join(node.path, "_")) + "Keys")
+	runes[0] = unicode.ToLower(runes[0])
+
+	return string(runes)
+}
+
+// ToIdentifier converts the translation key into the exported Go identifier:
+// "greeting.hello_name" becomes "GreetingHelloName".
+func ToIdentifier(key string) string {
+	parts := strings.FieldsFunc(key, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	var sb strings.Builder
+
+	for _, part := range parts {
+		runes := []rune(part)
+		runes[0] = unicode.ToUpper(runes[0])
+
+		sb.WriteString(string(runes))
+	}
+
+	name := sb.String()
+	if name == "" || !unicode.IsUpper([]rune(name)[0]) {
+		name = "K" + name
+	}
+
+	return name
+}
+
+// ToPackageName converts the directory name into the Go package name, empty if it is not possible.
+func ToPackageName(dir string) string {
+	var sb strings.Builder
+
+	for _, r := range strings.ToLower(dir) {
+		if r < unicode.MaxASCII && (unicode.IsLetter(r) || (unicode.IsDigit(r) && sb.Len() > 0)) {
+			sb.WriteRune(r)
+		}
+	}
+
+	return sb.String()
+}
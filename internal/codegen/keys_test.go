@@ -0,0 +1,114 @@
+package codegen_test
+
+import (
+	"testing"
+
+	"github.com/kukymbr/i18n/internal/codegen"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateKeys(t *testing.T) {
+	tests := []struct {
+		Name     string
+		Keys     []string
+		Mode     codegen.KeysMode
+		Expected string
+	}{
+		{
+			Name: "const",
+			Keys: []string{"greeting.hello_name", "greeting.hello", "404", "greeting.hello"},
+			Mode: codegen.KeysConst,
+			Expected: `// Code generated by i18n gen. DO NOT EDIT.
+
+package keys
+
+// Translation keys.
+const (
+	K404              = "404"
+	GreetingHello     = "greeting.hello"
+	GreetingHelloName = "greeting.hello_name"
+)
+`,
+		},
+		{
+			Name: "tree",
+			Keys: []string{"title", "errors.nested.not_found", "errors.internal"},
+			Mode: codegen.KeysTree,
+			Expected: `// Code generated by i18n gen. DO NOT EDIT.
+
+package keys
+
+// Errors contains keys of the "errors" group.
+var Errors = errorsKeys{
+	Internal: "errors.internal",
+	Nested: errorsNestedKeys{
+		NotFound: "errors.nested.not_found",
+	},
+}
+
+// Title is the "title" translation key.
+const Title = "title"
+
+type errorsKeys struct {
+	Internal string
+	Nested   errorsNestedKeys
+}
+
+type errorsNestedKeys struct {
+	NotFound string
+}
+`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			data, err := codegen.GenerateKeys(test.Keys, codegen.KeysOptions{Package: "keys", Mode: test.Mode})
+
+			require.NoError(t, err)
+			assert.Equal(t, test.Expected, string(data))
+		})
+	}
+}
+
+func TestGenerateKeys_Errors(t *testing.T) {
+	tests := []struct {
+		Name    string
+		Keys    []string
+		Options codegen.KeysOptions
+	}{
+		{Name: "no package", Keys: []string{"hello"}},
+		{Name: "unknown mode", Keys: []string{"hello"}, Options: codegen.KeysOptions{Package: "keys", Mode: "enum"}},
+		{
+			Name:    "const identifiers collision",
+			Keys:    []string{"hello.name", "hello_name"},
+			Options: codegen.KeysOptions{Package: "keys", Mode: codegen.KeysConst},
+		},
+		{
+			Name:    "tree key is a group",
+			Keys:    []string{"greeting", "greeting.hello"},
+			Options: codegen.KeysOptions{Package: "keys", Mode: codegen.KeysTree},
+		},
+		{
+			Name:    "tree identifiers collision",
+			Keys:    []string{"greeting.hello_name", "greeting.hello-name"},
+			Options: codegen.KeysOptions{Package: "keys", Mode: codegen.KeysTree},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			_, err := codegen.GenerateKeys(test.Keys, test.Options)
+
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestToIdentifier(t *testing.T) {
+	assert.Equal(t, "GreetingHelloName", codegen.ToIdentifier("greeting.hello_name"))
+	assert.Equal(t, "ErrorsNotFound", codegen.ToIdentifier("errors.not-found"))
+	assert.Equal(t, "K404Title", codegen.ToIdentifier("404.title"))
+	assert.Equal(t, "K", codegen.ToIdentifier("..."))
+}
@@ -0,0 +1,316 @@
+package codegen
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"go/token"
+	"go/types"
+	"slices"
+	"strconv"
+	"strings"
+	"text/template/parse"
+	"unicode"
+)
+
+// Message is a translation of the fallback language.
+type Message struct {
+	Key  string
+	Text string
+}
+
+// MessagesOptions are the options of the GenerateMessages.
+type MessagesOptions struct {
+	Package string
+}
+
+// Types of the parameters used as arguments of the built-in template functions.
+var funcParamTypes = map[string]map[int]string{
+	"plural": {0: "int"},
+	"number": {0: "float64"},
+	"date":   {1: "time.Time"},
+	"upper":  {0: "string"},
+	"lower":  {0: "string"},
+}
+
+const (
+	paramTypeString = "string"
+	paramTypeAny    = "any"
+)
+
+// messageParam is a parameter of the message function.
+type messageParam struct {
+	field string
+	name  string
+	typ   string
+}
+
+// GenerateMessages returns the formatted Go source with a function per message.
+// Function parameters are the top-level fields used by the message template:
+// `Hello, {{ .Name }}!` becomes `func GreetingHelloName(tr i18n.Translator, name string) string`.
+// Types are inferred from the built-in functions the fields are passed to: int for the plural,
+// float64 for the number, time.Time for the date; fields used by the other functions are of the any type.
+func GenerateMessages(messages []Message, opts MessagesOptions) ([]byte, error) {
+	if opts.Package == "" {
+		return nil, fmt.Errorf("package name is required")
+	}
+
+	messages = slices.Clone(messages)
+	slices.SortFunc(messages, func(a, b Message) int {
+		return strings.Compare(a.Key, b.Key)
+	})
+
+	var (
+		body    bytes.Buffer
+		useTime bool
+	)
+
+	names := make(map[string]string, len(messages))
+
+	for _, msg := range messages {
+		name := ToIdentifier(msg.Key)
+		if other, ok := names[name]; ok {
+			return nil, fmt.Errorf("keys %q and %q have the same identifier %s", other, msg.Key, name)
+		}
+
+		names[name] = msg.Key
+
+		params, err := getMessageParams(msg.Text)
+		if err != nil {
+			return nil, fmt.Errorf("key %s: %w", msg.Key, err)
+		}
+
+		for _, p := range params {
+			useTime = useTime || p.typ == "time.Time"
+		}
+
+		writeMessageFunc(&body, name, msg, params)
+	}
+
+	var buf bytes.Buffer
+
+	buf.WriteString(generatedHeader)
+	buf.WriteString("package " + opts.Package + "\n\n")
+	buf.WriteString("import (\n")
+
+	if useTime {
+		buf.WriteString("\"time\"\n\n")
+	}
+
+	buf.WriteString("\"github.com/kukymbr/i18n\"\n)\n\n")
+	buf.Write(body.Bytes())
+
+	return format.Source(buf.Bytes())
+}
+
+func writeMessageFunc(buf *bytes.Buffer, name string, msg Message, params []messageParam) {
+	buf.WriteString("// " + name + " translates the " + strconv.Quote(msg.Key) + " message: " + getCommentText(msg.Text) + "\n")
+	buf.WriteString("func " + name + "(tr i18n.Translator")
+
+	for _, p := range params {
+		buf.WriteString(", " + p.name + " " + p.typ)
+	}
+
+	buf.WriteString(") string {\n")
+
+	if len(params) == 0 {
+		buf.WriteString("return tr.T(" + strconv.Quote(msg.Key) + ")\n}\n\n")
+
+		return
+	}
+
+	buf.WriteString("return tr.T(" + strconv.Quote(msg.Key) + ", map[string]any{\n")
+
+	for _, p := range params {
+		buf.WriteString(strconv.Quote(p.field) + ": " + p.name + ",\n")
+	}
+
+	buf.WriteString("})\n}\n\n")
+}
+
+func getCommentText(text string) string {
+	const maxLen = 80
+
+	text = strings.Join(strings.Fields(text), " ")
+
+	if runes := []rune(text); len(runes) > maxLen {
+		return strings.TrimSpace(string(runes[:maxLen])) + "..."
+	}
+
+	return text
+}
+
+// getMessageParams returns parameters of the message in order of their appearance in the text.
+func getMessageParams(text string) ([]messageParam, error) {
+	if !strings.Contains(text, "{{") {
+		return nil, nil
+	}
+
+	tree := parse.New("message")
+	tree.Mode = parse.SkipFuncCheck
+
+	if _, err := tree.Parse(text, "", "", make(map[string]*parse.Tree)); err != nil {
+		return nil, fmt.Errorf("failed to parse template: %w", err)
+	}
+
+	c := &paramsCollector{types: make(map[string]string)}
+	c.walk(tree.Root, false)
+
+	params := make([]messageParam, 0, len(c.order))
+	names := make(map[string]struct{}, len(c.order))
+
+	for _, field := range c.order {
+		name := toParamName(field)
+		for _, taken := names[name]; taken; _, taken = names[name] {
+			name += "_"
+		}
+
+		names[name] = struct{}{}
+
+		params = append(params, messageParam{field: field, name: name, typ: c.types[field]})
+	}
+
+	return params, nil
+}
+
+type paramsCollector struct {
+	order []string
+	types map[string]string
+}
+
+// add registers the top-level field with the type; conflicting types result in the any type.
+func (c *paramsCollector) add(ident []string, typ string) {
+	field := ident[0]
+	if len(ident) > 1 {
+		typ = paramTypeAny
+	}
+
+	current, ok := c.types[field]
+	if !ok {
+		c.order = append(c.order, field)
+		c.types[field] = typ
+
+		return
+	}
+
+	if current != typ {
+		c.types[field] = paramTypeAny
+	}
+}
+
+// walk collects fields of the node; in nested with and range blocks, only the $-rooted fields are collected,
+// as the rest are relative to the new dot.
+//
+//nolint:cyclop // plain switch over the node types
+func (c *paramsCollector) walk(node parse.Node, nested bool) {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return
+		}
+
+		for _, child := range n.Nodes {
+			c.walk(child, nested)
+		}
+	case *parse.ActionNode:
+		c.walkPipe(n.Pipe, paramTypeString, nested)
+	case *parse.IfNode:
+		c.walkPipe(n.Pipe, paramTypeAny, nested)
+		c.walk(n.List, nested)
+		c.walk(n.ElseList, nested)
+	case *parse.WithNode:
+		c.walkPipe(n.Pipe, paramTypeAny, nested)
+		c.walk(n.List, true)
+		c.walk(n.ElseList, nested)
+	case *parse.RangeNode:
+		c.walkPipe(n.Pipe, paramTypeAny, nested)
+		c.walk(n.List, true)
+		c.walk(n.ElseList, nested)
+	case *parse.TemplateNode:
+		c.walkPipe(n.Pipe, paramTypeAny, nested)
+	}
+}
+
+// walkPipe collects fields of the pipeline; typ is the type of the field printed as is.
+func (c *paramsCollector) walkPipe(pipe *parse.PipeNode, typ string, nested bool) {
+	if pipe == nil {
+		return
+	}
+
+	for i, cmd := range pipe.Cmds {
+		fn := ""
+		if ident, ok := cmd.Args[0].(*parse.IdentifierNode); ok {
+			fn = ident.Ident
+		}
+
+		for j, arg := range cmd.Args {
+			var ident []string
+
+			switch a := arg.(type) {
+			case *parse.FieldNode:
+				if !nested {
+					ident = a.Ident
+				}
+			case *parse.VariableNode:
+				if len(a.Ident) > 1 && a.Ident[0] == "$" {
+					ident = a.Ident[1:]
+				}
+			}
+
+			if ident == nil {
+				continue
+			}
+
+			argTyp := typ
+
+			switch {
+			case fn != "":
+				argTyp = getFuncParamType(fn, j-1)
+			case len(cmd.Args) > 1:
+				argTyp = paramTypeAny
+			case i < len(pipe.Cmds)-1:
+				argTyp = getPipedParamType(pipe.Cmds[i+1])
+			}
+
+			c.add(ident, argTyp)
+		}
+	}
+}
+
+func getFuncParamType(fn string, index int) string {
+	if typ, ok := funcParamTypes[fn][index]; ok {
+		return typ
+	}
+
+	return paramTypeAny
+}
+
+// getPipedParamType returns the type of the value piped into the command, passed as its last argument.
+func getPipedParamType(next *parse.CommandNode) string {
+	ident, ok := next.Args[0].(*parse.IdentifierNode)
+	if !ok {
+		return paramTypeAny
+	}
+
+	return getFuncParamType(ident.Ident, len(next.Args)-1)
+}
+
+func toParamName(field string) string {
+	runes := []rune(field)
+	for i := 0; i < len(runes) && unicode.IsUpper(runes[i]); i++ {
+		// Lowercase the leading initialism: ID -> id, URLPath -> urlPath.
+		if i > 0 && i+1 < len(runes) && unicode.IsLower(runes[i+1]) {
+			break
+		}
+
+		runes[i] = unicode.ToLower(runes[i])
+	}
+
+	// Keywords, predeclared identifiers (any, string, len) and names used by the generated code are renamed.
+	name := string(runes)
+	if token.IsKeyword(name) || types.Universe.Lookup(name) != nil || name == "tr" || name == "i18n" || name == "time" {
+		name += "Value"
+	}
+
+	return name
+}
@@ -0,0 +1,102 @@
+package codegen_test
+
+import (
+	"testing"
+
+	"github.com/kukymbr/i18n/internal/codegen"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateMessages(t *testing.T) {
+	messages := []codegen.Message{
+		{Key: "greeting.hello_name", Text: "Hello, {{ .Name }}!"},
+		{Key: "greeting.hello", Text: "Hello!"},
+		{
+			Key: "cart.summary",
+			Text: `{{ .UserID }}: {{ number .Total }} {{ plural .Count "one=item" "other=items" }} ` +
+				`since {{ .Since | date "02.01.2006" }}{{ if .Sale }}, sale{{ end }}`,
+		},
+		{Key: "list", Text: `{{ range .Items }}{{ .Title }} by {{ $.Author.Name }}{{ end }}, {{ .Type }}`},
+		{Key: "predeclared", Text: `{{ .Any }} {{ .String }} {{ .Len }}`},
+	}
+
+	data, err := codegen.GenerateMessages(messages, codegen.MessagesOptions{Package: "messages"})
+	require.NoError(t, err)
+
+	assert.Equal(t, `// Code generated by i18n gen. DO NOT EDIT.
+
+package messages
+
+import (
+	"time"
+
+	"github.com/kukymbr/i18n"
+)
+
+// CartSummary translates the "cart.summary" message: {{ .UserID }}: {{ number .Total }} {{ plural .Count "one=item" "other=items" }}...
+func CartSummary(tr i18n.Translator, userID string, total float64, count int, since time.Time, sale any) string {
+	return tr.T("cart.summary", map[string]any{
+		"UserID": userID,
+		"Total":  total,
+		"Count":  count,
+		"Since":  since,
+		"Sale":   sale,
+	})
+}
+
+// GreetingHello translates the "greeting.hello" message: Hello!
+func GreetingHello(tr i18n.Translator) string {
+	return tr.T("greeting.hello")
+}
+
+// GreetingHelloName translates the "greeting.hello_name" message: Hello, {{ .Name }}!
+func GreetingHelloName(tr i18n.Translator, name string) string {
+	return tr.T("greeting.hello_name", map[string]any{
+		"Name": name,
+	})
+}
+
+// List translates the "list" message: {{ range .Items }}{{ .Title }} by {{ $.Author.Name }}{{ end }}, {{ .Type }}
+func List(tr i18n.Translator, items any, author any, typeValue string) string {
+	return tr.T("list", map[string]any{
+		"Items":  items,
+		"Author": author,
+		"Type":   typeValue,
+	})
+}
+
+// Predeclared translates the "predeclared" message: {{ .Any }} {{ .String }} {{ .Len }}
+func Predeclared(tr i18n.Translator, anyValue string, stringValue string, lenValue string) string {
+	return tr.T("predeclared", map[string]any{
+		"Any":    anyValue,
+		"String": stringValue,
+		"Len":    lenValue,
+	})
+}
+`, string(data))
+}
+
+func TestGenerateMessages_Errors(t *testing.T) {
+	tests := []struct {
+		Name     string
+		Messages []codegen.Message
+		Package  string
+	}{
+		{Name: "no package", Messages: []codegen.Message{{Key: "hello", Text: "Hello"}}},
+		{Name: "broken template", Messages: []codegen.Message{{Key: "hello", Text: "{{ .Name "}}, Package: "messages"},
+		{
+			Name:     "identifiers collision",
+			Messages: []codegen.Message{{Key: "hello.name", Text: "Hello"}, {Key: "hello_name", Text: "Hello"}},
+			Package:  "messages",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			_, err := codegen.GenerateMessages(test.Messages, codegen.MessagesOptions{Package: test.Package})
+
+			require.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,537 @@
+// Package convert writes the bundle's translations in the formats of the other localization tools.
+package convert
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+	"text/template/parse"
+	"unicode"
+
+	"github.com/kukymbr/i18n"
+	"github.com/kukymbr/i18n/internal/codegen"
+	"github.com/kukymbr/i18n/internal/gettext"
+	"gopkg.in/yaml.v3"
+)
+
+// Format is an output format.
+type Format string
+
+const (
+	// FormatJSON is the package's JSON layout with the nested keys.
+	FormatJSON Format = "json"
+	// FormatYAML is the package's YAML layout with the nested keys.
+	FormatYAML Format = "yaml"
+	// FormatPO is a gettext PO file with the keys as the message ids.
+	FormatPO Format = "po"
+	// FormatXLIFF is an XLIFF 1.2 file with the fallback language's texts as the sources.
+	FormatXLIFF Format = "xliff"
+	// FormatCSV is a single table of all the languages: key, fallback language, other languages.
+	FormatCSV Format = "csv"
+	// FormatARB is a Flutter application resource bundle.
+	FormatARB Format = "arb"
+)
+
+// File is an output file.
+type File struct {
+	Name string
+	Data []byte
+}
+
+// Convert returns the translations of the export in the format.
+// A file per language `<lang>.<ext>` is returned, except the CSV format having a single `translations.csv`.
+// Keys are nested by the dots in the JSON and YAML formats same as the bundle parses them, and flat in the others.
+// If the languages are given, only they are converted.
+func Convert(export i18n.BundleExport, format Format, langs ...i18n.Tag) ([]File, error) {
+	fallback := getFallbackTranslations(export)
+
+	if len(langs) > 0 {
+		export.Languages = slices.DeleteFunc(slices.Clone(export.Languages), func(lang i18n.LanguageExport) bool {
+			return !slices.Contains(langs, lang.Language)
+		})
+	}
+
+	if format == FormatCSV {
+		data, err := encodeCSV(export)
+		if err != nil {
+			return nil, err
+		}
+
+		return []File{{Name: "translations.csv", Data: data}}, nil
+	}
+
+	encode, ext, err := getEncoder(format)
+	if err != nil {
+		return nil, err
+	}
+
+	files := make([]File, 0, len(export.Languages))
+
+	for _, lang := range export.Languages {
+		data, err := encode(lang, export.FallbackLanguage, fallback)
+		if err != nil {
+			return nil, fmt.Errorf("language %s: %w", lang.Language, err)
+		}
+
+		files = append(files, File{Name: lang.Language.String() + "." + ext, Data: data})
+	}
+
+	return files, nil
+}
+
+type encodeFunc func(lang i18n.LanguageExport, fallbackLang i18n.Tag, fallback i18n.Translations) ([]byte, error)
+
+func getEncoder(format Format) (encodeFunc, string, error) {
+	switch format {
+	case FormatJSON:
+		return encodeJSON, "json", nil
+	case FormatYAML:
+		return encodeYAML, "yaml", nil
+	case FormatPO:
+		return encodePO, "po", nil
+	case FormatXLIFF:
+		return encodeXLIFF, "xlf", nil
+	case FormatARB:
+		return encodeARB, "arb", nil
+	default:
+		return nil, "", fmt.Errorf("unknown format %q, expected json, yaml, po, xliff, csv or arb", format)
+	}
+}
+
+func getFallbackTranslations(export i18n.BundleExport) i18n.Translations {
+	for _, lang := range export.Languages {
+		if lang.Language == export.FallbackLanguage {
+			return lang.Translations
+		}
+	}
+
+	return nil
+}
+
+// translationsDTO is the package's translations file layout.
+type translationsDTO struct {
+	Language     string         `json:"language" yaml:"language"`
+	Translations map[string]any `json:"translations" yaml:"translations"`
+}
+
+func newTranslationsDTO(lang i18n.LanguageExport) translationsDTO {
+	return translationsDTO{Language: lang.Language.String(), Translations: nestKeys(lang.Translations)}
+}
+
+// nestKeys splits the keys by the dots into the nested maps.
+// If a part of the key is a translation itself, the rest of the key is kept flat:
+// keys `menu` and `menu.item` stay in the same map, which is parsed back to the same keys.
+func nestKeys(translations i18n.Translations) map[string]any {
+	root := make(map[string]any)
+
+	for _, key := range slices.Sorted(maps.Keys(translations)) {
+		parent := root
+		parts := strings.Split(key, ".")
+		name := parts[len(parts)-1]
+
+		for i, part := range parts[:len(parts)-1] {
+			child, ok := parent[part]
+			if !ok {
+				child = make(map[string]any)
+				parent[part] = child
+			}
+
+			nested, ok := child.(map[string]any)
+			if !ok {
+				name = strings.Join(parts[i:], ".")
+
+				break
+			}
+
+			parent = nested
+		}
+
+		parent[name] = translations[key]
+	}
+
+	return root
+}
+
+func encodeJSON(lang i18n.LanguageExport, _ i18n.Tag, _ i18n.Translations) ([]byte, error) {
+	var buf bytes.Buffer
+
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+
+	if err := enc.Encode(newTranslationsDTO(lang)); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func encodeYAML(lang i18n.LanguageExport, _ i18n.Tag, _ i18n.Translations) ([]byte, error) {
+	var buf bytes.Buffer
+
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+
+	if err := enc.Encode(newTranslationsDTO(lang)); err != nil {
+		return nil, err
+	}
+
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func encodePO(lang i18n.LanguageExport, _ i18n.Tag, _ i18n.Translations) ([]byte, error) {
+	keys := slices.Sorted(maps.Keys(lang.Translations))
+	entries := make([]gettext.Entry, 0, len(keys))
+
+	for _, key := range keys {
+		entries = append(entries, gettext.Entry{MsgID: key, MsgStr: lang.Translations[key]})
+	}
+
+	return gettext.Encode([]string{
+		"Language: " + lang.Language.String(),
+		"MIME-Version: 1.0",
+		"Content-Type: text/plain; charset=UTF-8",
+		"Content-Transfer-Encoding: 8bit",
+	}, entries), nil
+}
+
+type xliffDocument struct {
+	XMLName xml.Name  `xml:"urn:oasis:names:tc:xliff:document:1.2 xliff"`
+	Version string    `xml:"version,attr"`
+	File    xliffFile `xml:"file"`
+}
+
+type xliffFile struct {
+	SourceLanguage string      `xml:"source-language,attr"`
+	TargetLanguage string      `xml:"target-language,attr"`
+	Datatype       string      `xml:"datatype,attr"`
+	Original       string      `xml:"original,attr"`
+	Units          []xliffUnit `xml:"body>trans-unit"`
+}
+
+type xliffUnit struct {
+	ID     string  `xml:"id,attr"`
+	Source string  `xml:"source"`
+	Target *string `xml:"target"`
+}
+
+// encodeXLIFF returns the XLIFF file with the keys of the fallback and the target languages.
+// Keys not translated to the target language have no target element.
+func encodeXLIFF(lang i18n.LanguageExport, fallbackLang i18n.Tag, fallback i18n.Translations) ([]byte, error) {
+	keys := make(map[string]struct{}, len(fallback))
+
+	for key := range fallback {
+		keys[key] = struct{}{}
+	}
+
+	for key := range lang.Translations {
+		keys[key] = struct{}{}
+	}
+
+	doc := xliffDocument{
+		Version: "1.2",
+		File: xliffFile{
+			SourceLanguage: fallbackLang.String(),
+			TargetLanguage: lang.Language.String(),
+			Datatype:       "plaintext",
+			Original:       "i18n",
+		},
+	}
+
+	for _, key := range slices.Sorted(maps.Keys(keys)) {
+		unit := xliffUnit{ID: key, Source: fallback[key]}
+
+		if text, ok := lang.Translations[key]; ok {
+			unit.Target = &text
+
+			if _, ok := fallback[key]; !ok {
+				unit.Source = text
+			}
+		}
+
+		doc.File.Units = append(doc.File.Units, unit)
+	}
+
+	data, err := xml.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]byte(xml.Header), append(data, '\n')...), nil
+}
+
+// encodeARB returns the ARB file; the locale is in the `@@locale` field.
+// Keys are converted into the camelCase resource ids accepted by the Flutter gen-l10n,
+// template fields and plural functions into the ICU placeholders (see the toARBMessage);
+// the original key and the placeholders are in the `@<id>` metadata.
+func encodeARB(lang i18n.LanguageExport, _ i18n.Tag, _ i18n.Translations) ([]byte, error) {
+	var buf bytes.Buffer
+
+	buf.WriteString("{\n")
+
+	if err := writeJSONField(&buf, "@@locale", strings.ReplaceAll(lang.Language.String(), "-", "_")); err != nil {
+		return nil, err
+	}
+
+	ids := make(map[string]string, len(lang.Translations))
+
+	for _, key := range slices.Sorted(maps.Keys(lang.Translations)) {
+		id := toARBResourceID(key)
+		if other, ok := ids[id]; ok {
+			return nil, fmt.Errorf("keys %q and %q have the same ARB resource id %q", other, key, id)
+		}
+
+		ids[id] = key
+
+		text, placeholders, err := toARBMessage(lang.Translations[key])
+		if err != nil {
+			return nil, fmt.Errorf("key %s: %w", key, err)
+		}
+
+		meta := arbMetadata{Key: key, Placeholders: placeholders}
+
+		buf.WriteString(",\n")
+
+		if err := writeJSONField(&buf, id, text); err != nil {
+			return nil, err
+		}
+
+		buf.WriteString(",\n")
+
+		if err := writeJSONField(&buf, "@"+id, meta); err != nil {
+			return nil, err
+		}
+	}
+
+	buf.WriteString("\n}\n")
+
+	return buf.Bytes(), nil
+}
+
+// arbMetadata is an `@<id>` attribute of the ARB resource.
+type arbMetadata struct {
+	Key          string                    `json:"x-key"`
+	Placeholders map[string]arbPlaceholder `json:"placeholders,omitempty"`
+}
+
+// arbPlaceholder is a declaration of the placeholder, the type is required for the plural ones.
+type arbPlaceholder struct {
+	Type string `json:"type,omitempty"`
+}
+
+// toARBResourceID converts the key into the camelCase identifier: "greeting.hello_name" becomes "greetingHelloName".
+func toARBResourceID(key string) string {
+	runes := []rune(codegen.ToIdentifier(key))
+	runes[0] = unicode.ToLower(runes[0])
+
+	return string(runes)
+}
+
+// rxARBPlaceholder matches the ICU message format arguments: `{name}` or `{count, plural, ...}`.
+var rxARBPlaceholder = regexp.MustCompile(`\{\s*([\p{L}_][\p{L}\p{N}_]*)\s*[,}]`)
+
+// toARBMessage converts the text into the ICU message and returns it with its placeholders:
+// template fields `{{ .Name }}` become the `{name}` placeholders and the plural functions
+// `{{ plural .Count "one=item" "other=items" }}` become the `{count, plural, one{item} other{items}}` ones.
+// Other template actions could not be converted.
+func toARBMessage(text string) (string, map[string]arbPlaceholder, error) {
+	placeholders := make(map[string]arbPlaceholder)
+
+	if !strings.Contains(text, "{{") {
+		collectARBPlaceholders(text, placeholders)
+
+		return text, getNonEmpty(placeholders), nil
+	}
+
+	tree := parse.New("arb")
+	tree.Mode = parse.SkipFuncCheck
+
+	if _, err := tree.Parse(text, "", "", make(map[string]*parse.Tree)); err != nil {
+		return "", nil, fmt.Errorf("failed to parse template: %w", err)
+	}
+
+	var sb strings.Builder
+
+	for _, node := range tree.Root.Nodes {
+		switch n := node.(type) {
+		case *parse.TextNode:
+			collectARBPlaceholders(string(n.Text), placeholders)
+			sb.Write(n.Text)
+		case *parse.ActionNode:
+			arg, err := toARBArgument(n, placeholders)
+			if err != nil {
+				return "", nil, err
+			}
+
+			sb.WriteString(arg)
+		default:
+			return "", nil, fmt.Errorf("template action %s is not supported by ARB", node)
+		}
+	}
+
+	return sb.String(), getNonEmpty(placeholders), nil
+}
+
+// toARBArgument converts the field or the plural function action into the ICU argument.
+func toARBArgument(action *parse.ActionNode, placeholders map[string]arbPlaceholder) (string, error) {
+	unsupported := fmt.Errorf("template action %s is not supported by ARB", action)
+
+	if len(action.Pipe.Decl) > 0 || len(action.Pipe.Cmds) != 1 {
+		return "", unsupported
+	}
+
+	args := action.Pipe.Cmds[0].Args
+
+	if name, ok := getARBPlaceholderName(args[0]); ok && len(args) == 1 {
+		if _, ok := placeholders[name]; !ok {
+			placeholders[name] = arbPlaceholder{}
+		}
+
+		return "{" + name + "}", nil
+	}
+
+	fn, ok := args[0].(*parse.IdentifierNode)
+	if !ok || fn.Ident != "plural" || len(args) < 3 {
+		return "", unsupported
+	}
+
+	name, ok := getARBPlaceholderName(args[1])
+	if !ok {
+		return "", unsupported
+	}
+
+	var sb strings.Builder
+
+	sb.WriteString("{" + name + ", plural,")
+
+	for _, arg := range args[2:] {
+		str, ok := arg.(*parse.StringNode)
+		if !ok {
+			return "", unsupported
+		}
+
+		form, text, ok := strings.Cut(str.Text, "=")
+		if !ok {
+			return "", unsupported
+		}
+
+		sb.WriteString(" " + form + "{" + text + "}")
+	}
+
+	sb.WriteString("}")
+
+	placeholders[name] = arbPlaceholder{Type: "num"}
+
+	return sb.String(), nil
+}
+
+// getARBPlaceholderName returns the placeholder name of the top-level field: `.Name` becomes "name".
+func getARBPlaceholderName(node parse.Node) (string, bool) {
+	field, ok := node.(*parse.FieldNode)
+	if !ok || len(field.Ident) != 1 {
+		return "", false
+	}
+
+	runes := []rune(field.Ident[0])
+	runes[0] = unicode.ToLower(runes[0])
+
+	return string(runes), true
+}
+
+func collectARBPlaceholders(text string, placeholders map[string]arbPlaceholder) {
+	for _, match := range rxARBPlaceholder.FindAllStringSubmatch(text, -1) {
+		if _, ok := placeholders[match[1]]; !ok {
+			placeholders[match[1]] = arbPlaceholder{}
+		}
+	}
+}
+
+func getNonEmpty[M ~map[K]V, K comparable, V any](m M) M {
+	if len(m) == 0 {
+		return nil
+	}
+
+	return m
+}
+
+func writeJSONField(buf *bytes.Buffer, name string, value any) error {
+	var field bytes.Buffer
+
+	enc := json.NewEncoder(&field)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("  ", "  ")
+
+	if err := enc.Encode(name); err != nil {
+		return err
+	}
+
+	buf.WriteString("  " + strings.TrimSuffix(field.String(), "\n") + ": ")
+	field.Reset()
+
+	if err := enc.Encode(value); err != nil {
+		return err
+	}
+
+	buf.WriteString(strings.TrimSuffix(field.String(), "\n"))
+
+	return nil
+}
+
+// encodeCSV returns the table with a row per key and a column per language, the fallback language first.
+func encodeCSV(export i18n.BundleExport) ([]byte, error) {
+	languages := slices.Clone(export.Languages)
+	slices.SortStableFunc(languages, func(a, b i18n.LanguageExport) int {
+		switch {
+		case a.Language == export.FallbackLanguage:
+			return -1
+		case b.Language == export.FallbackLanguage:
+			return 1
+		default:
+			return 0
+		}
+	})
+
+	keys := make(map[string]struct{})
+	header := []string{"key"}
+
+	for _, lang := range languages {
+		header = append(header, lang.Language.String())
+
+		for key := range lang.Translations {
+			keys[key] = struct{}{}
+		}
+	}
+
+	var buf bytes.Buffer
+
+	w := csv.NewWriter(&buf)
+
+	if err := w.Write(header); err != nil {
+		return nil, err
+	}
+
+	for _, key := range slices.Sorted(maps.Keys(keys)) {
+		row := []string{key}
+		for _, lang := range languages {
+			row = append(row, lang.Translations[key])
+		}
+
+		if err := w.Write(row); err != nil {
+			return nil, err
+		}
+	}
+
+	w.Flush()
+
+	return buf.Bytes(), w.Error()
+}
@@ -0,0 +1,249 @@
+package convert_test
+
+import (
+	"testing"
+
+	"github.com/kukymbr/i18n"
+	"github.com/kukymbr/i18n/internal/convert"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func getTestExport(t *testing.T) i18n.BundleExport {
+	t.Helper()
+
+	bundle, err := i18n.NewBundle(
+		i18n.English,
+		i18n.FromString(i18n.YAML, `
+language: en
+translations:
+  menu: Menu
+  menu.home: Home
+  greeting:
+    hello: "<b>Hello</b>, {{ .Name }}!"
+`),
+		i18n.FromString(i18n.JSON, `{"language": "pt-BR", "translations": {"menu": "Menu", "only_pt": "Só \"aqui\""}}`),
+	)
+	require.NoError(t, err)
+
+	return bundle.GetBundleExport()
+}
+
+func TestConvert(t *testing.T) {
+	export := getTestExport(t)
+
+	tests := []struct {
+		Format   convert.Format
+		Expected map[string]string
+	}{
+		{
+			Format: convert.FormatJSON,
+			Expected: map[string]string{
+				"en.json": `{
+  "language": "en",
+  "translations": {
+    "greeting": {
+      "hello": "<b>Hello</b>, {{ .Name }}!"
+    },
+    "menu": "Menu",
+    "menu.home": "Home"
+  }
+}
+`,
+			},
+		},
+		{
+			Format: convert.FormatYAML,
+			Expected: map[string]string{
+				"pt-BR.yaml": `language: pt-BR
+translations:
+  menu: Menu
+  only_pt: Só "aqui"
+`,
+			},
+		},
+		{
+			Format: convert.FormatPO,
+			Expected: map[string]string{
+				"pt-BR.po": `msgid ""
+msgstr ""
+"Language: pt-BR\n"
+"MIME-Version: 1.0\n"
+"Content-Type: text/plain; charset=UTF-8\n"
+"Content-Transfer-Encoding: 8bit\n"
+
+msgid "menu"
+msgstr "Menu"
+
+msgid "only_pt"
+msgstr "Só \"aqui\""
+`,
+			},
+		},
+		{
+			Format: convert.FormatXLIFF,
+			Expected: map[string]string{
+				"pt-BR.xlf": `<?xml version="1.0" encoding="UTF-8"?>
+<xliff xmlns="urn:oasis:names:tc:xliff:document:1.2" version="1.2">
+  <file source-language="en" target-language="pt-BR" datatype="plaintext" original="i18n">
+    <body>
+      <trans-unit id="greeting.hello">
+        <source>&lt;b&gt;Hello&lt;/b&gt;, {{ .Name }}!</source>
+      </trans-unit>
+      <trans-unit id="menu">
+        <source>Menu</source>
+        <target>Menu</target>
+      </trans-unit>
+      <trans-unit id="menu.home">
+        <source>Home</source>
+      </trans-unit>
+      <trans-unit id="only_pt">
+        <source>Só &#34;aqui&#34;</source>
+        <target>Só &#34;aqui&#34;</target>
+      </trans-unit>
+    </body>
+  </file>
+</xliff>
+`,
+			},
+		},
+		{
+			Format: convert.FormatARB,
+			Expected: map[string]string{
+				"en.arb": `{
+  "@@locale": "en",
+  "greetingHello": "<b>Hello</b>, {name}!",
+  "@greetingHello": {
+    "x-key": "greeting.hello",
+    "placeholders": {
+      "name": {}
+    }
+  },
+  "menu": "Menu",
+  "@menu": {
+    "x-key": "menu"
+  },
+  "menuHome": "Home",
+  "@menuHome": {
+    "x-key": "menu.home"
+  }
+}
+`,
+				"pt-BR.arb": `{
+  "@@locale": "pt_BR",
+  "menu": "Menu",
+  "@menu": {
+    "x-key": "menu"
+  },
+  "onlyPt": "Só \"aqui\"",
+  "@onlyPt": {
+    "x-key": "only_pt"
+  }
+}
+`,
+			},
+		},
+		{
+			Format: convert.FormatCSV,
+			Expected: map[string]string{
+				"translations.csv": `key,en,pt-BR
+greeting.hello,"<b>Hello</b>, {{ .Name }}!",
+menu,Menu,Menu
+menu.home,Home,
+only_pt,,"Só ""aqui"""
+`,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(string(test.Format), func(t *testing.T) {
+			files, err := convert.Convert(export, test.Format)
+			require.NoError(t, err)
+
+			for _, file := range files {
+				if expected, ok := test.Expected[file.Name]; ok {
+					assert.Equal(t, expected, string(file.Data))
+					delete(test.Expected, file.Name)
+				}
+			}
+
+			assert.Empty(t, test.Expected, "files are missing")
+		})
+	}
+
+	t.Run("languages", func(t *testing.T) {
+		files, err := convert.Convert(export, convert.FormatXLIFF, i18n.MustParse("pt-BR"))
+		require.NoError(t, err)
+		require.Len(t, files, 1)
+		assert.Contains(t, string(files[0].Data), "<source>Home</source>")
+
+		files, err = convert.Convert(export, convert.FormatCSV, i18n.English)
+		require.NoError(t, err)
+		assert.Contains(t, string(files[0].Data), "key,en\n")
+	})
+
+	t.Run("when ARB resource ids collide", func(t *testing.T) {
+		bundle, err := i18n.NewBundle(i18n.English, i18n.FromString(i18n.YAML, "translations: {menu.home: Home, menu_home: Home}"))
+		require.NoError(t, err)
+
+		_, err = convert.Convert(bundle.GetBundleExport(), convert.FormatARB)
+		require.Error(t, err)
+	})
+
+	t.Run("when ARB message has plural", func(t *testing.T) {
+		bundle, err := i18n.NewBundle(i18n.English, i18n.FromString(
+			i18n.YAML,
+			`translations: {cart: '{{ .Name }} has {{ plural .Count "one=# item" "other=# items" }}'}`,
+		))
+		require.NoError(t, err)
+
+		files, err := convert.Convert(bundle.GetBundleExport(), convert.FormatARB)
+		require.NoError(t, err)
+		require.Len(t, files, 1)
+		assert.Contains(t, string(files[0].Data), `"cart": "{name} has {count, plural, one{# item} other{# items}}"`)
+		assert.Contains(t, string(files[0].Data), `"count": {`+"\n"+`        "type": "num"`)
+	})
+
+	t.Run("when ARB message has unsupported action", func(t *testing.T) {
+		bundle, err := i18n.NewBundle(i18n.English, i18n.FromString(i18n.YAML, `translations: {title: '{{ t "menu.home" }}'}`))
+		require.NoError(t, err)
+
+		_, err = convert.Convert(bundle.GetBundleExport(), convert.FormatARB)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "title")
+	})
+
+	t.Run("unknown", func(t *testing.T) {
+		_, err := convert.Convert(export, "xml")
+		require.Error(t, err)
+	})
+}
+
+func TestConvert_RoundTrip(t *testing.T) {
+	export := getTestExport(t)
+
+	for _, format := range []convert.Format{convert.FormatJSON, convert.FormatYAML} {
+		t.Run(string(format), func(t *testing.T) {
+			files, err := convert.Convert(export, format)
+			require.NoError(t, err)
+
+			dataType := i18n.JSON
+			if format == convert.FormatYAML {
+				dataType = i18n.YAML
+			}
+
+			sources := make([]i18n.BundleSource, 0, len(files))
+			for _, file := range files {
+				sources = append(sources, i18n.FromBytes(dataType, file.Data))
+			}
+
+			bundle, err := i18n.NewBundle(i18n.English, sources...)
+			require.NoError(t, err)
+
+			for _, lang := range export.Languages {
+				assert.Equal(t, lang.Translations, bundle.GetLanguageExport(lang.Language).Translations)
+			}
+		})
+	}
+}
@@ -0,0 +1,327 @@
+// Package extract finds translation keys used in the Go source code.
+package extract
+
+import (
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/kukymbr/i18n/internal/tagsparser"
+)
+
+const i18nImportPath = "github.com/kukymbr/i18n"
+
+// translateFuncs are names of the package functions and of the methods with the `key` argument.
+var translateFuncs = []string{"T", "Translate", "TranslateE", "Tf"}
+
+// Key is a translation key found in the source code.
+type Key struct {
+	Key string
+	// Positions are the places the key is used at, sorted by the file and line.
+	Positions []token.Position
+}
+
+// Options are the options of the Extract.
+type Options struct {
+	// Tests enables extraction from the _test.go files.
+	Tests bool
+}
+
+// Extract parses Go files matched by the patterns and returns the translation keys sorted by the key.
+// A pattern is a directory, `./...`-like patterns are walked recursively
+// skipping the testdata, vendor and hidden directories.
+//
+// Keys are collected from the string literal `key` arguments of:
+//   - i18n.T, i18n.Translate, i18n.TranslateE and i18n.Tf functions;
+//   - T, Translate, TranslateE and Tf methods of the i18n package's types: the Bundle and the Translator;
+//
+// and from the keys of the `i18n` struct tags with their prefix options.
+// Method receivers are resolved by type-checking the packages, so the i18n package must be importable
+// by the go command from the working directory; the import failure is returned as an error.
+// Keys computed at runtime and prefixes of the parent structures are not known to the extractor.
+func Extract(patterns []string, opts Options) ([]Key, error) {
+	files, err := findFiles(patterns, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	e := &extractor{
+		fset: token.NewFileSet(),
+		keys: make(map[string][]token.Position),
+	}
+
+	packages := make(map[packageID][]*ast.File)
+	var order []packageID
+
+	for _, path := range files {
+		file := e.parseFile(path)
+		if file == nil {
+			continue
+		}
+
+		id := packageID{dir: filepath.Dir(path), name: file.Name.Name}
+		if _, ok := packages[id]; !ok {
+			order = append(order, id)
+		}
+
+		packages[id] = append(packages[id], file)
+	}
+
+	for _, id := range order {
+		e.inspectPackage(packages[id])
+	}
+
+	keys := make([]Key, 0, len(e.keys))
+
+	for key, positions := range e.keys {
+		slices.SortFunc(positions, comparePositions)
+		keys = append(keys, Key{Key: key, Positions: positions})
+	}
+
+	slices.SortFunc(keys, func(a, b Key) int {
+		return strings.Compare(a.Key, b.Key)
+	})
+
+	return keys, errors.Join(e.errs...)
+}
+
+func findFiles(patterns []string, opts Options) ([]string, error) {
+	var files []string
+
+	for _, pattern := range patterns {
+		dir, recursive := strings.CutSuffix(filepath.ToSlash(pattern), "/...")
+		if pattern == "..." {
+			dir, recursive = ".", true
+		}
+
+		err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+
+			if d.IsDir() {
+				if path != dir && (!recursive || isIgnoredDir(d.Name())) {
+					return filepath.SkipDir
+				}
+
+				return nil
+			}
+
+			if isSourceFile(d.Name(), opts) {
+				files = append(files, path)
+			}
+
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", pattern, err)
+		}
+	}
+
+	slices.Sort(files)
+
+	return slices.Compact(files), nil
+}
+
+func isIgnoredDir(name string) bool {
+	return name == "testdata" || name == "vendor" || strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_")
+}
+
+func isSourceFile(name string, opts Options) bool {
+	if !strings.HasSuffix(name, ".go") || strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_") {
+		return false
+	}
+
+	return opts.Tests || !strings.HasSuffix(name, "_test.go")
+}
+
+// packageID identifies the package of the files, external test packages are separate ones.
+type packageID struct {
+	dir  string
+	name string
+}
+
+// sourceImporter type-checks the imported packages from the source. It is shared by the Extract calls,
+// as checking of the i18n package with its dependencies takes a while.
+var (
+	sourceImporterMu sync.Mutex
+	sourceImporter   = importer.ForCompiler(token.NewFileSet(), "source", nil)
+)
+
+type extractor struct {
+	fset *token.FileSet
+	keys map[string][]token.Position
+	errs []error
+}
+
+func (e *extractor) parseFile(path string) *ast.File {
+	src, err := os.ReadFile(path)
+	if err != nil {
+		e.errs = append(e.errs, fmt.Errorf("failed to read %s: %w", path, err))
+
+		return nil
+	}
+
+	file, err := parser.ParseFile(e.fset, filepath.ToSlash(path), src, parser.SkipObjectResolution)
+	if err != nil {
+		e.errs = append(e.errs, err)
+
+		return nil
+	}
+
+	return file
+}
+
+func (e *extractor) inspectPackage(files []*ast.File) {
+	info := &types.Info{Uses: make(map[*ast.Ident]types.Object)}
+
+	var importErr error
+
+	sourceImporterMu.Lock()
+
+	// Other errors are ignored: the calls with the resolved functions are enough,
+	// but no call is resolved if the i18n package could not be imported.
+	conf := types.Config{Importer: sourceImporter, Error: func(err error) {
+		var typeErr types.Error
+		if importErr == nil && errors.As(err, &typeErr) && strings.HasPrefix(typeErr.Msg, "could not import "+i18nImportPath+" ") {
+			importErr = err
+		}
+	}}
+	_, _ = conf.Check(files[0].Name.Name, e.fset, files, info)
+
+	sourceImporterMu.Unlock()
+
+	if importErr != nil {
+		e.errs = append(e.errs, fmt.Errorf("translation calls are not resolved: %w", importErr))
+	}
+
+	for _, file := range files {
+		ast.Inspect(file, func(node ast.Node) bool {
+			switch n := node.(type) {
+			case *ast.CallExpr:
+				e.inspectCall(n, info)
+			case *ast.StructType:
+				e.inspectStruct(n)
+			}
+
+			return true
+		})
+	}
+}
+
+// inspectCall adds the key of the i18n package's translation function or method call.
+func (e *extractor) inspectCall(call *ast.CallExpr, info *types.Info) {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || !slices.Contains(translateFuncs, sel.Sel.Name) {
+		return
+	}
+
+	fn, ok := info.Uses[sel.Sel].(*types.Func)
+	if !ok || fn.Pkg() == nil || fn.Pkg().Path() != i18nImportPath {
+		return
+	}
+
+	sig, ok := fn.Type().(*types.Signature)
+	if !ok {
+		return
+	}
+
+	for i := range sig.Params().Len() {
+		if sig.Params().At(i).Name() == "key" {
+			if i < len(call.Args) {
+				e.addLiteral(call.Args[i])
+			}
+
+			return
+		}
+	}
+}
+
+// addLiteral adds the key if the expression is a string literal.
+func (e *extractor) addLiteral(expr ast.Expr) {
+	lit, ok := expr.(*ast.BasicLit)
+	if !ok || lit.Kind != token.STRING {
+		return
+	}
+
+	if key, err := strconv.Unquote(lit.Value); err == nil && key != "" {
+		e.add(key, lit.Pos())
+	}
+}
+
+func (e *extractor) inspectStruct(st *ast.StructType) {
+	var (
+		prefix string
+		tagged []*ast.Field
+		tags   []tagsparser.Tag
+	)
+
+	for _, field := range st.Fields.List {
+		tag, ok := e.parseTag(field)
+		if !ok {
+			continue
+		}
+
+		if len(field.Names) == 1 && field.Names[0].Name == "_" {
+			prefix = tag.Prefix
+
+			continue
+		}
+
+		tagged = append(tagged, field)
+		tags = append(tags, tag)
+	}
+
+	for i, tag := range tags {
+		if !tag.Skip && tag.Key != "" {
+			e.add(prefix+tag.Prefix+tag.Key, tagged[i].Tag.Pos())
+		}
+	}
+}
+
+// parseTag returns the parsed `i18n` tag of the field, false if there is no valid one.
+func (e *extractor) parseTag(field *ast.Field) (tagsparser.Tag, bool) {
+	if field.Tag == nil {
+		return tagsparser.Tag{}, false
+	}
+
+	tagValue, _ := strconv.Unquote(field.Tag.Value)
+
+	value, ok := reflect.StructTag(tagValue).Lookup(tagsparser.TagName)
+	if !ok {
+		return tagsparser.Tag{}, false
+	}
+
+	tag, err := tagsparser.ParseTag(value)
+	if err != nil {
+		e.errs = append(e.errs, fmt.Errorf("%s: %w", e.fset.Position(field.Tag.Pos()), err))
+
+		return tagsparser.Tag{}, false
+	}
+
+	return tag, true
+}
+
+func (e *extractor) add(key string, pos token.Pos) {
+	e.keys[key] = append(e.keys[key], e.fset.Position(pos))
+}
+
+func comparePositions(a, b token.Position) int {
+	if c := strings.Compare(a.Filename, b.Filename); c != 0 {
+		return c
+	}
+
+	return a.Line - b.Line
+}
@@ -0,0 +1,154 @@
+package extract_test
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/kukymbr/i18n/internal/extract"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExtract(t *testing.T) {
+	tests := []struct {
+		Name     string
+		Patterns []string
+		Opts     extract.Options
+		Expected map[string][]string
+	}{
+		{
+			Name:     "recursive",
+			Patterns: []string{"testdata/app/..."},
+			Expected: map[string][]string{
+				"cart.items":          {"testdata/app/handlers/handler.go:8"},
+				"common.app_name":     {"testdata/app/handlers/handler.go:7"},
+				"errors.help.hint":    {"testdata/app/main.go:14"},
+				"errors.not_found":    {"testdata/app/main.go:11"},
+				"greeting.hello":      {"testdata/app/handlers/handler.go:6", "testdata/app/main.go:18"},
+				"greeting.hello_name": {"testdata/app/main.go:19"},
+				"menu":                {"testdata/app/handlers/handler.go:9"},
+				"menu.settings":       {"testdata/app/handlers/handler.go:10"},
+			},
+		},
+		{
+			Name:     "single directory with tests",
+			Patterns: []string{"testdata/app/handlers"},
+			Opts:     extract.Options{Tests: true},
+			Expected: map[string][]string{
+				"cart.items":      {"testdata/app/handlers/handler.go:8"},
+				"common.app_name": {"testdata/app/handlers/handler.go:7"},
+				"greeting.hello":  {"testdata/app/handlers/handler.go:6"},
+				"menu":            {"testdata/app/handlers/handler.go:9"},
+				"menu.settings":   {"testdata/app/handlers/handler.go:10"},
+				"test.only":       {"testdata/app/handlers/handler_test.go:6"},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			keys, err := extract.Extract(test.Patterns, test.Opts)
+			require.NoError(t, err)
+
+			found := make(map[string][]string, len(keys))
+
+			for _, key := range keys {
+				for _, pos := range key.Positions {
+					found[key.Key] = append(found[key.Key], pos.Filename+":"+strconv.Itoa(pos.Line))
+				}
+			}
+
+			assert.Equal(t, test.Expected, found)
+		})
+	}
+}
+
+func TestExtract_Errors(t *testing.T) {
+	t.Run("missing directory", func(t *testing.T) {
+		_, err := extract.Extract([]string{"testdata/missing"}, extract.Options{})
+		require.Error(t, err)
+	})
+
+	t.Run("invalid sources", func(t *testing.T) {
+		dir := t.TempDir()
+
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "syntax.go"), []byte("package broken\n\nfunc {"), 0o600))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "tag.go"), []byte(
+			"package broken\n\ntype dto struct {\n\tName string `i18n:\"name,unknown=1\"`\n}\n",
+		), 0o600))
+
+		_, err := extract.Extract([]string{dir}, extract.Options{})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "syntax.go:3")
+		assert.Contains(t, err.Error(), `tag.go:4:14: unknown i18n tag option "unknown"`)
+	})
+
+	t.Run("when i18n package is not importable", func(t *testing.T) {
+		dir := t.TempDir()
+		// Packages are resolved by the go command from the working directory, which is outside any module.
+		t.Chdir(dir)
+
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "main.go"), []byte(
+			"package main\n\nimport \"github.com/kukymbr/i18n\"\n\nvar bundle *i18n.Bundle\n\n"+
+				"func main() {\n\tbundle.T(i18n.English, \"greeting.hello\")\n}\n",
+		), 0o600))
+
+		_, err := extract.Extract([]string{dir}, extract.Options{})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "main.go:3:8: could not import github.com/kukymbr/i18n")
+	})
+}
+
+func TestEncode(t *testing.T) {
+	keys, err := extract.Extract([]string{"testdata/app/handlers"}, extract.Options{})
+	require.NoError(t, err)
+
+	t.Run("pot", func(t *testing.T) {
+		data, err := extract.Encode(keys[:2], extract.FormatPOT, "en")
+		require.NoError(t, err)
+
+		assert.Equal(t, `msgid ""
+msgstr ""
+"Content-Type: text/plain; charset=UTF-8\n"
+
+#: testdata/app/handlers/handler.go:8
+msgid "cart.items"
+msgstr ""
+
+#: testdata/app/handlers/handler.go:7
+msgid "common.app_name"
+msgstr ""
+`, string(data))
+	})
+
+	t.Run("yaml", func(t *testing.T) {
+		data, err := extract.Encode(keys, extract.FormatYAML, "es")
+		require.NoError(t, err)
+
+		assert.Equal(t, `language: es
+translations:
+  cart:
+    items: "" # testdata/app/handlers/handler.go:8
+  common:
+    app_name: "" # testdata/app/handlers/handler.go:7
+  greeting:
+    hello: "" # testdata/app/handlers/handler.go:6
+  menu: "" # testdata/app/handlers/handler.go:9
+  menu.settings: "" # testdata/app/handlers/handler.go:10
+`, string(data))
+	})
+
+	t.Run("json", func(t *testing.T) {
+		data, err := extract.Encode(keys[3:4], extract.FormatJSON, "en")
+		require.NoError(t, err)
+
+		assert.JSONEq(t, `[{"key": "menu", "positions": ["testdata/app/handlers/handler.go:9"]}]`, string(data))
+	})
+
+	t.Run("unknown", func(t *testing.T) {
+		_, err := extract.Encode(keys, "xml", "en")
+		require.Error(t, err)
+	})
+}
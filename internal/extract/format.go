@@ -0,0 +1,164 @@
+package extract
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/kukymbr/i18n/internal/gettext"
+	"gopkg.in/yaml.v3"
+)
+
+// Format is an output format of the extracted keys.
+type Format string
+
+const (
+	// FormatPOT is a gettext template with the keys as the message ids.
+	FormatPOT Format = "pot"
+	// FormatYAML is a translation file skeleton with empty translations.
+	FormatYAML Format = "yaml"
+	// FormatJSON is a list of the keys with their positions.
+	FormatJSON Format = "json"
+)
+
+// Encode returns the keys in the format; the language is the language of the YAML skeleton.
+func Encode(keys []Key, format Format, lang string) ([]byte, error) {
+	switch format {
+	case FormatPOT:
+		return encodePOT(keys), nil
+	case FormatYAML:
+		return encodeYAML(keys, lang)
+	case FormatJSON:
+		return encodeJSON(keys)
+	default:
+		return nil, fmt.Errorf("unknown format %q, expected pot, yaml or json", format)
+	}
+}
+
+func encodePOT(keys []Key) []byte {
+	entries := make([]gettext.Entry, 0, len(keys))
+
+	for _, key := range keys {
+		entry := gettext.Entry{MsgID: key.Key, References: make([]string, 0, len(key.Positions))}
+
+		for _, pos := range key.Positions {
+			entry.References = append(entry.References, formatPosition(pos.Filename, pos.Line))
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return gettext.Encode([]string{"Content-Type: text/plain; charset=UTF-8"}, entries)
+}
+
+type jsonKey struct {
+	Key       string   `json:"key"`
+	Positions []string `json:"positions"`
+}
+
+func encodeJSON(keys []Key) ([]byte, error) {
+	list := make([]jsonKey, 0, len(keys))
+
+	for _, key := range keys {
+		item := jsonKey{Key: key.Key, Positions: make([]string, 0, len(key.Positions))}
+
+		for _, pos := range key.Positions {
+			item.Positions = append(item.Positions, formatPosition(pos.Filename, pos.Line))
+		}
+
+		list = append(list, item)
+	}
+
+	data, err := json.MarshalIndent(list, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	return append(data, '\n'), nil
+}
+
+// encodeYAML returns the translation file with the keys nested by the dots and empty translations.
+// Positions of the keys are added as the comments.
+func encodeYAML(keys []Key, lang string) ([]byte, error) {
+	translations := &yaml.Node{Kind: yaml.MappingNode}
+
+	for _, key := range keys {
+		positions := make([]string, 0, len(key.Positions))
+		for _, pos := range key.Positions {
+			positions = append(positions, formatPosition(pos.Filename, pos.Line))
+		}
+
+		parent, name := getYAMLParent(translations, key.Key)
+		parent.Content = append(parent.Content,
+			&yaml.Node{Kind: yaml.ScalarNode, Value: name},
+			&yaml.Node{
+				Kind:        yaml.ScalarNode,
+				Tag:         "!!str",
+				Style:       yaml.DoubleQuotedStyle,
+				LineComment: strings.Join(positions, " "),
+			},
+		)
+	}
+
+	doc := &yaml.Node{Kind: yaml.MappingNode, Content: []*yaml.Node{
+		{Kind: yaml.ScalarNode, Value: "language"},
+		{Kind: yaml.ScalarNode, Value: lang},
+		{Kind: yaml.ScalarNode, Value: "translations"},
+		translations,
+	}}
+
+	var buf bytes.Buffer
+
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+
+	if err := enc.Encode(doc); err != nil {
+		return nil, err
+	}
+
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// getYAMLParent returns the mapping node to add the key to and the key's name in it.
+// If a part of the key is already a translation, the rest of the key is added as is:
+// keys `menu` and `menu.item` become `menu` and `menu.item` of the same mapping.
+func getYAMLParent(root *yaml.Node, key string) (*yaml.Node, string) {
+	parent := root
+	parts := strings.Split(key, ".")
+
+	for i, part := range parts[:len(parts)-1] {
+		child := findYAMLValue(parent, part)
+
+		switch {
+		case child == nil:
+			child = &yaml.Node{Kind: yaml.MappingNode}
+			parent.Content = append(parent.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: part}, child)
+		case child.Kind != yaml.MappingNode:
+			return parent, strings.Join(parts[i:], ".")
+		}
+
+		parent = child
+	}
+
+	return parent, parts[len(parts)-1]
+}
+
+func findYAMLValue(mapping *yaml.Node, name string) *yaml.Node {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == name {
+			return mapping.Content[i+1]
+		}
+	}
+
+	return nil
+}
+
+func formatPosition(file string, line int) string {
+	return file + ":" + strconv.Itoa(line)
+}
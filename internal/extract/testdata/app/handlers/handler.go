@@ -0,0 +1,11 @@
+package handlers
+
+import "github.com/kukymbr/i18n"
+
+func Handle(bundle *i18n.Bundle, translator i18n.Translator) {
+	_ = bundle.T(i18n.English, "greeting.hello")
+	_, _ = bundle.TranslateE(i18n.English, "common.app_name")
+	_ = bundle.Tf(i18n.English, "cart.items", i18n.Arg("Count", 2))
+	_ = translator.T("menu")
+	_ = translator.T("menu.settings")
+}
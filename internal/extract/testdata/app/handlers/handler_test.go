@@ -0,0 +1,7 @@
+package handlers
+
+import "github.com/kukymbr/i18n"
+
+func testKeys() {
+	_ = i18n.T(i18n.English, "test.only")
+}
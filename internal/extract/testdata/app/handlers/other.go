@@ -0,0 +1,19 @@
+package handlers
+
+import (
+	"context"
+
+	"github.com/kukymbr/i18n"
+)
+
+type client struct{}
+
+func (client) T(key string) string { return key }
+
+func (client) Translate(_ context.Context, key string) string { return key }
+
+func Other(c client, translator i18n.Translator, key string) {
+	_ = c.T("not.a.key")
+	_ = c.Translate(context.Background(), "not.a.key.either")
+	_ = translator.T(key, "not.a.key.data")
+}
@@ -0,0 +1,23 @@
+package main
+
+import (
+	"fmt"
+
+	tr "github.com/kukymbr/i18n"
+)
+
+type errorDTO struct {
+	_       struct{} `i18n:",prefix=errors."`
+	Message string   `i18n:"not_found"`
+	Code    string   `json:"code"`
+	Title   string   `i18n:"-"`
+	Hint    string   `i18n:"hint,prefix=help."`
+}
+
+func main() {
+	fmt.Println(tr.T(tr.English, "greeting.hello"))
+	fmt.Println(tr.Translate(tr.Spanish, "greeting.hello_name", map[string]any{"Name": "Mateo"}))
+
+	key := "common.dynamic"
+	fmt.Println(tr.T(tr.English, key))
+}
@@ -0,0 +1,7 @@
+package testdata
+
+import "github.com/kukymbr/i18n"
+
+func ignored() {
+	_ = i18n.T(i18n.English, "ignored")
+}
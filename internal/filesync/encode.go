@@ -0,0 +1,116 @@
+package filesync
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const indent = "  "
+
+func encodeYAML(doc *yaml.Node) ([]byte, error) {
+	var buf bytes.Buffer
+
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(len(indent))
+
+	if err := enc.Encode(doc); err != nil {
+		return nil, err
+	}
+
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// encodeJSON writes the node as JSON keeping the order of the keys, which the encoding/json does not.
+func encodeJSON(node *yaml.Node) ([]byte, error) {
+	var buf bytes.Buffer
+
+	if err := writeJSONNode(&buf, node, ""); err != nil {
+		return nil, err
+	}
+
+	buf.WriteByte('\n')
+
+	return buf.Bytes(), nil
+}
+
+func writeJSONNode(buf *bytes.Buffer, node *yaml.Node, prefix string) error {
+	switch node.Kind { //nolint:exhaustive // documents and aliases are not expected in the translation files
+	case yaml.MappingNode:
+		return writeJSONCollection(buf, node.Content, 2, '{', '}', prefix)
+	case yaml.SequenceNode:
+		return writeJSONCollection(buf, node.Content, 1, '[', ']', prefix)
+	default:
+		return writeJSONScalar(buf, node)
+	}
+}
+
+// writeJSONCollection writes the mapping (step 2, keys and values) or the sequence (step 1).
+func writeJSONCollection(buf *bytes.Buffer, content []*yaml.Node, step int, open byte, closing byte, prefix string) error {
+	buf.WriteByte(open)
+
+	if len(content) == 0 {
+		buf.WriteByte(closing)
+
+		return nil
+	}
+
+	inner := prefix + indent
+
+	for i := 0; i+step-1 < len(content); i += step {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+
+		buf.WriteString("\n" + inner)
+
+		if step == 2 {
+			if err := writeJSONString(buf, content[i].Value); err != nil {
+				return err
+			}
+
+			buf.WriteString(": ")
+		}
+
+		if err := writeJSONNode(buf, content[i+step-1], inner); err != nil {
+			return err
+		}
+	}
+
+	buf.WriteString("\n" + prefix)
+	buf.WriteByte(closing)
+
+	return nil
+}
+
+func writeJSONScalar(buf *bytes.Buffer, node *yaml.Node) error {
+	switch node.ShortTag() {
+	case "!!int", "!!float", "!!bool", "!!null":
+		buf.WriteString(node.Value)
+
+		return nil
+	default:
+		return writeJSONString(buf, node.Value)
+	}
+}
+
+func writeJSONString(buf *bytes.Buffer, s string) error {
+	var str strings.Builder
+
+	enc := json.NewEncoder(&str)
+	enc.SetEscapeHTML(false)
+
+	if err := enc.Encode(s); err != nil {
+		return err
+	}
+
+	buf.WriteString(strings.TrimSuffix(str.String(), "\n"))
+
+	return nil
+}
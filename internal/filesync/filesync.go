@@ -0,0 +1,394 @@
+// Package filesync keeps translation files of the target languages in sync with the source language's ones.
+package filesync
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	languageField     = "language"
+	translationsField = "translations"
+)
+
+// FillMode defines the text of the keys added to the target files.
+type FillMode string
+
+const (
+	// FillEmpty adds the keys with the empty text.
+	FillEmpty FillMode = "empty"
+	// FillSource adds the keys with the text of the source language.
+	FillSource FillMode = "source"
+)
+
+// Options are the options of the Sync.
+type Options struct {
+	// Fill is the FillSource for the YAML files and the FillEmpty for the JSON ones by default,
+	// as the JSON files could not mark the added keys as untranslated.
+	Fill FillMode
+	// Marker is a comment added to the keys added to the YAML files, e.g. `TODO: translate`.
+	Marker string
+	// KeepStale disables removing of the keys absent in the source file.
+	KeepStale bool
+}
+
+// Result is a result of the Sync.
+type Result struct {
+	Data []byte
+	// Added are the keys added to the target file.
+	Added []string
+	// Removed are the stale keys removed from the target file.
+	Removed []string
+}
+
+// Sync returns the target file updated to have the same keys as the source file.
+// Existing translations, comments and order of the target's keys are preserved,
+// new keys are inserted after their preceding siblings of the source file.
+// Keys are matched in the flattened form, so the `a.b` and the `b` nested in the `a` are the same key:
+// translations nested differently than in the source file are moved to the source file's structure.
+// If the target is empty, a new file is created. JSON files are detected by the path's extension.
+func Sync(path string, source []byte, target []byte, lang string, opts Options) (Result, error) {
+	srcDoc, err := parseDocument(source)
+	if err != nil {
+		return Result{}, fmt.Errorf("failed to parse source file: %w", err)
+	}
+
+	var dstDoc *yaml.Node
+
+	if len(bytes.TrimSpace(target)) > 0 {
+		if dstDoc, err = parseDocument(target); err != nil {
+			return Result{}, fmt.Errorf("failed to parse %s: %w", path, err)
+		}
+	} else {
+		dstDoc = &yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{{Kind: yaml.MappingNode}}}
+	}
+
+	srcTranslations := getField(srcDoc.Content[0], translationsField)
+	if srcTranslations == nil || srcTranslations.Kind != yaml.MappingNode {
+		return Result{}, errors.New("source file has no translations")
+	}
+
+	root := dstDoc.Content[0]
+	setField(root, languageField, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: lang})
+
+	dstTranslations := getField(root, translationsField)
+	if dstTranslations == nil || dstTranslations.Kind != yaml.MappingNode {
+		dstTranslations = &yaml.Node{Kind: yaml.MappingNode}
+		setField(root, translationsField, dstTranslations)
+	}
+
+	if opts.Fill == "" {
+		opts.Fill = FillSource
+		if isJSON(path) {
+			opts.Fill = FillEmpty
+		}
+	}
+
+	s := newSyncer(dstTranslations, opts)
+	s.syncMapping(srcTranslations, dstTranslations, "")
+	s.prune(dstTranslations)
+
+	result := Result{Added: s.added, Removed: s.getRemoved()}
+
+	if isJSON(path) {
+		result.Data, err = encodeJSON(root)
+	} else {
+		result.Data, err = encodeYAML(dstDoc)
+	}
+
+	return result, err
+}
+
+// ReadLanguage returns the value of the file's language field.
+func ReadLanguage(data []byte) (string, error) {
+	doc, err := parseDocument(data)
+	if err != nil {
+		return "", err
+	}
+
+	field := getField(doc.Content[0], languageField)
+	if field == nil || field.Kind != yaml.ScalarNode {
+		return "", errors.New("language field is missing")
+	}
+
+	return field.Value, nil
+}
+
+// GetTargetPath returns the path of the target language's file in the root directory:
+// segments of the path relative to the root equal to the source language are replaced
+// (`common.en.yaml` -> `common.es.yaml`, `en/common.json` -> `es/common.json`);
+// if there are none, the language is added before the extension.
+func GetTargetPath(root string, path string, sourceLang string, targetLang string) string {
+	if rel, err := filepath.Rel(root, path); root != "" && err == nil && filepath.IsLocal(rel) {
+		return filepath.Join(root, GetTargetPath("", rel, sourceLang, targetLang))
+	}
+
+	dir, name := filepath.Split(path)
+	ext := filepath.Ext(name)
+
+	parts := strings.Split(strings.TrimSuffix(name, ext), ".")
+	dirs := strings.Split(filepath.ToSlash(filepath.Clean(dir)), "/")
+	replaced := false
+
+	for _, segments := range [][]string{parts, dirs} {
+		for i, segment := range segments {
+			if strings.EqualFold(segment, sourceLang) {
+				segments[i] = targetLang
+				replaced = true
+			}
+		}
+	}
+
+	if !replaced {
+		parts = append(parts, targetLang)
+	}
+
+	name = strings.Join(parts, ".") + ext
+	if dir == "" {
+		return name
+	}
+
+	return filepath.Join(filepath.FromSlash(strings.Join(dirs, "/")), name)
+}
+
+func isJSON(path string) bool {
+	return strings.EqualFold(filepath.Ext(path), ".json")
+}
+
+func parseDocument(data []byte) (*yaml.Node, error) {
+	var doc yaml.Node
+
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+
+	if doc.Kind != yaml.DocumentNode || len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		return nil, errors.New("expected a mapping with the language and translations fields")
+	}
+
+	return &doc, nil
+}
+
+// getField returns the value of the mapping's field, nil if there is no such field.
+func getField(mapping *yaml.Node, name string) *yaml.Node {
+	if i := findKey(mapping, name); i >= 0 {
+		return mapping.Content[i+1]
+	}
+
+	return nil
+}
+
+func setField(mapping *yaml.Node, name string, value *yaml.Node) {
+	if i := findKey(mapping, name); i >= 0 {
+		value.LineComment = mapping.Content[i+1].LineComment
+		mapping.Content[i+1] = value
+
+		return
+	}
+
+	mapping.Content = append(mapping.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: name}, value)
+}
+
+// findKey returns the index of the key node in the mapping's content, -1 if not found.
+func findKey(mapping *yaml.Node, name string) int {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == name {
+			return i
+		}
+	}
+
+	return -1
+}
+
+type syncer struct {
+	opts Options
+	// existing are the target's translations by the flattened keys.
+	existing map[string]*yaml.Node
+	// moved are the target's translations moved to another place in the file.
+	moved   map[*yaml.Node]struct{}
+	added   []string
+	removed []leaf
+}
+
+// leaf is a translation of the target file.
+type leaf struct {
+	key  string
+	node *yaml.Node
+}
+
+func newSyncer(dst *yaml.Node, opts Options) *syncer {
+	s := &syncer{
+		opts:     opts,
+		existing: make(map[string]*yaml.Node),
+		moved:    make(map[*yaml.Node]struct{}),
+	}
+
+	for _, l := range collectLeaves(nil, dst, "") {
+		if _, ok := s.existing[l.key]; !ok {
+			s.existing[l.key] = l.node
+		}
+	}
+
+	return s
+}
+
+func (s *syncer) syncMapping(src *yaml.Node, dst *yaml.Node, prefix string) {
+	sourceKeys := make(map[string]struct{}, len(src.Content)/2)
+	insertAt := 0
+
+	for i := 0; i+1 < len(src.Content); i += 2 {
+		srcKey, srcValue := src.Content[i], src.Content[i+1]
+		sourceKeys[srcKey.Value] = struct{}{}
+		key := prefix + srcKey.Value
+
+		j := findKey(dst, srcKey.Value)
+		if j < 0 {
+			dst.Content = insertPair(dst.Content, insertAt, s.newKey(srcKey), s.newValue(srcValue, key))
+			insertAt += 2
+
+			continue
+		}
+
+		dstValue := dst.Content[j+1]
+
+		switch {
+		case srcValue.Kind == yaml.MappingNode && dstValue.Kind == yaml.MappingNode:
+			s.syncMapping(srcValue, dstValue, key+".")
+		case srcValue.Kind == yaml.MappingNode || dstValue.Kind == yaml.MappingNode:
+			// The key changed from a translation to a group or vice versa.
+			s.removed = collectLeaves(s.removed, dstValue, key)
+			dst.Content[j+1] = s.newValue(srcValue, key)
+		}
+
+		insertAt = max(insertAt, j+2)
+	}
+
+	if s.opts.KeepStale {
+		return
+	}
+
+	content := dst.Content[:0]
+
+	for i := 0; i+1 < len(dst.Content); i += 2 {
+		if _, ok := sourceKeys[dst.Content[i].Value]; ok {
+			content = append(content, dst.Content[i], dst.Content[i+1])
+
+			continue
+		}
+
+		s.removed = collectLeaves(s.removed, dst.Content[i+1], prefix+dst.Content[i].Value)
+	}
+
+	dst.Content = content
+}
+
+// prune removes the moved translations from their original places
+// and the groups left empty after that.
+func (s *syncer) prune(mapping *yaml.Node) {
+	content := mapping.Content[:0]
+
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		value := mapping.Content[i+1]
+
+		if _, ok := s.moved[value]; ok {
+			continue
+		}
+
+		if value.Kind == yaml.MappingNode && len(value.Content) > 0 {
+			if s.prune(value); len(value.Content) == 0 {
+				continue
+			}
+		}
+
+		content = append(content, mapping.Content[i], value)
+	}
+
+	mapping.Content = content
+}
+
+// getRemoved returns the keys of the removed translations, except the moved ones.
+func (s *syncer) getRemoved() []string {
+	var keys []string
+
+	for _, l := range s.removed {
+		if _, ok := s.moved[l.node]; !ok {
+			keys = append(keys, l.key)
+		}
+	}
+
+	return keys
+}
+
+func (s *syncer) newKey(src *yaml.Node) *yaml.Node {
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: src.Tag, Style: src.Style, Value: src.Value}
+}
+
+// newValue returns the value for the target file with the structure of the source one.
+func (s *syncer) newValue(src *yaml.Node, key string) *yaml.Node {
+	switch src.Kind { //nolint:exhaustive // other kinds are copied as is
+	case yaml.MappingNode:
+		node := &yaml.Node{Kind: yaml.MappingNode, Style: src.Style}
+
+		for i := 0; i+1 < len(src.Content); i += 2 {
+			node.Content = append(node.Content, s.newKey(src.Content[i]), s.newValue(src.Content[i+1], key+"."+src.Content[i].Value))
+		}
+
+		return node
+	case yaml.ScalarNode:
+		if node, ok := s.existing[key]; ok {
+			if _, ok := s.moved[node]; !ok {
+				s.moved[node] = struct{}{}
+				moved := *node
+
+				return &moved
+			}
+		}
+
+		s.added = append(s.added, key)
+
+		node := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", LineComment: s.opts.Marker}
+		if s.opts.Fill == FillSource {
+			node.Style = src.Style
+			node.Value = src.Value
+		} else {
+			node.Style = yaml.DoubleQuotedStyle
+		}
+
+		return node
+	default:
+		s.added = append(s.added, key)
+
+		return src
+	}
+}
+
+func insertPair(content []*yaml.Node, at int, key *yaml.Node, value *yaml.Node) []*yaml.Node {
+	content = append(content, nil, nil)
+	copy(content[at+2:], content[at:])
+	content[at], content[at+1] = key, value
+
+	return content
+}
+
+// collectLeaves appends the translations in the node to the list.
+func collectLeaves(leaves []leaf, node *yaml.Node, key string) []leaf {
+	if node.Kind != yaml.MappingNode {
+		return append(leaves, leaf{key: key, node: node})
+	}
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		childKey := node.Content[i].Value
+		if key != "" {
+			childKey = key + "." + childKey
+		}
+
+		leaves = collectLeaves(leaves, node.Content[i+1], childKey)
+	}
+
+	return leaves
+}
@@ -0,0 +1,239 @@
+package filesync_test
+
+import (
+	"testing"
+
+	"github.com/kukymbr/i18n/internal/filesync"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const sourceYAML = `# Common texts.
+language: en
+translations:
+  # Shown on the main page.
+  hello: "Hello!"
+  menu:
+    home: Home
+    settings: Settings
+  bye: Bye!
+`
+
+func TestSync(t *testing.T) {
+	tests := []struct {
+		Name     string
+		Path     string
+		Source   string
+		Target   string
+		Opts     filesync.Options
+		Expected string
+		Added    []string
+		Removed  []string
+	}{
+		{
+			Name:   "new yaml file",
+			Path:   "common.es.yaml",
+			Source: sourceYAML,
+			Opts:   filesync.Options{Fill: filesync.FillEmpty},
+			Expected: `language: es
+translations:
+  hello: ""
+  menu:
+    home: ""
+    settings: ""
+  bye: ""
+`,
+			Added: []string{"hello", "menu.home", "menu.settings", "bye"},
+		},
+		{
+			Name:   "existing yaml file",
+			Path:   "common.es.yaml",
+			Source: sourceYAML,
+			Target: `language: es # Spanish
+translations:
+  bye: ¡Adiós! # informal
+  # Saludo.
+  hello: "¡Hola!"
+  stale: Viejo
+  menu:
+    settings: Ajustes
+    old: Viejo
+`,
+			Opts: filesync.Options{Fill: filesync.FillSource, Marker: "TODO: translate"},
+			Expected: `language: es # Spanish
+translations:
+  bye: ¡Adiós! # informal
+  # Saludo.
+  hello: "¡Hola!"
+  menu:
+    home: Home # TODO: translate
+    settings: Ajustes
+`,
+			Added:   []string{"menu.home"},
+			Removed: []string{"menu.old", "stale"},
+		},
+		{
+			Name:   "keep stale keys",
+			Path:   "common.es.yaml",
+			Source: sourceYAML,
+			Target: `language: es
+translations:
+  hello: ¡Hola!
+  stale: Viejo
+  menu: Menú
+`,
+			Opts: filesync.Options{Fill: filesync.FillEmpty, KeepStale: true},
+			Expected: `language: es
+translations:
+  hello: ¡Hola!
+  stale: Viejo
+  menu:
+    home: ""
+    settings: ""
+  bye: ""
+`,
+			Added:   []string{"menu.home", "menu.settings", "bye"},
+			Removed: []string{"menu"},
+		},
+		{
+			Name:   "differently nested keys",
+			Path:   "common.es.yaml",
+			Source: sourceYAML,
+			Target: `language: es
+translations:
+  menu.home: Inicio
+  hello: ¡Hola!
+  menu.old: Viejo
+`,
+			Opts: filesync.Options{Fill: filesync.FillSource, Marker: "TODO: translate"},
+			Expected: `language: es
+translations:
+  hello: ¡Hola!
+  menu:
+    home: Inicio
+    settings: Settings # TODO: translate
+  bye: Bye! # TODO: translate
+`,
+			Added:   []string{"menu.settings", "bye"},
+			Removed: []string{"menu.old"},
+		},
+		{
+			Name: "flat source keys",
+			Path: "common.es.yaml",
+			Source: `language: en
+translations:
+  greeting.hello: Hello
+  greeting.bye: Bye
+`,
+			Target: `language: es
+translations:
+  greeting:
+    hello: Hola
+    old: Viejo
+`,
+			Opts: filesync.Options{Fill: filesync.FillEmpty, KeepStale: true},
+			Expected: `language: es
+translations:
+  greeting.hello: Hola
+  greeting.bye: ""
+  greeting:
+    old: Viejo
+`,
+			Added: []string{"greeting.bye"},
+		},
+		{
+			Name: "json file",
+			Path: "es/common.json",
+			Source: `{"language": "en", "translations": {
+				"title": "<b>Title</b>",
+				"count": "Count",
+				"nested": {"a": "A", "b": "B"}
+			}}`,
+			Target: `{"translations": {"nested": {"b": "B es", "a": "A es"}, "title": "<b>Título</b>"}, "language": "es"}`,
+			Opts:   filesync.Options{Fill: filesync.FillSource, Marker: "ignored in JSON"},
+			Expected: `{
+  "translations": {
+    "nested": {
+      "b": "B es",
+      "a": "A es"
+    },
+    "title": "<b>Título</b>",
+    "count": "Count"
+  },
+  "language": "es"
+}
+`,
+			Added: []string{"count"},
+		},
+		{
+			Name:   "json file with default fill",
+			Path:   "es/common.json",
+			Source: `{"language": "en", "translations": {"title": "Title", "count": "Count"}}`,
+			Target: `{"language": "es", "translations": {"title": "Título"}}`,
+			Opts:   filesync.Options{Marker: "TODO: translate"},
+			Expected: `{
+  "language": "es",
+  "translations": {
+    "title": "Título",
+    "count": ""
+  }
+}
+`,
+			Added: []string{"count"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			result, err := filesync.Sync(test.Path, []byte(test.Source), []byte(test.Target), "es", test.Opts)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.Expected, string(result.Data))
+			assert.Equal(t, test.Added, result.Added)
+			assert.Equal(t, test.Removed, result.Removed)
+		})
+	}
+}
+
+func TestSync_Errors(t *testing.T) {
+	_, err := filesync.Sync("es.yaml", []byte("language: en"), nil, "es", filesync.Options{})
+	require.Error(t, err)
+
+	_, err = filesync.Sync("es.yaml", []byte("- en"), nil, "es", filesync.Options{})
+	require.Error(t, err)
+
+	_, err = filesync.Sync("es.yaml", []byte(sourceYAML), []byte("language: [es"), "es", filesync.Options{})
+	require.Error(t, err)
+}
+
+func TestReadLanguage(t *testing.T) {
+	lang, err := filesync.ReadLanguage([]byte(sourceYAML))
+	require.NoError(t, err)
+	assert.Equal(t, "en", lang)
+
+	_, err = filesync.ReadLanguage([]byte("translations: {}"))
+	require.Error(t, err)
+}
+
+func TestGetTargetPath(t *testing.T) {
+	tests := []struct {
+		Root     string
+		Path     string
+		Expected string
+	}{
+		{"", "common.en.yaml", "common.es.yaml"},
+		{"", "translations/en.yml", "translations/es.yml"},
+		{"", "translations/en/common.json", "translations/es/common.json"},
+		{"", "translations/common.yaml", "translations/common.es.yaml"},
+		{"", "translations/EN.json", "translations/es.json"},
+		{"/x/en/t", "/x/en/t/common.en.yaml", "/x/en/t/common.es.yaml"},
+		{"/x/en/t", "/x/en/t/en/common.json", "/x/en/t/es/common.json"},
+		{"/x/en/t", "/x/en/t/common.yaml", "/x/en/t/common.es.yaml"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Path, func(t *testing.T) {
+			assert.Equal(t, test.Expected, filesync.GetTargetPath(test.Root, test.Path, "en", "es"))
+		})
+	}
+}
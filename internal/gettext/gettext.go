@@ -0,0 +1,46 @@
+// Package gettext writes the gettext PO and POT files.
+package gettext
+
+import (
+	"bytes"
+	"strings"
+)
+
+// Entry is a message of the PO file.
+type Entry struct {
+	// References are the source code positions of the message, `file:line`.
+	References []string
+	MsgID      string
+	MsgStr     string
+}
+
+// Encode returns the PO file with the header fields (`Language: es`) and the entries.
+func Encode(headers []string, entries []Entry) []byte {
+	var buf bytes.Buffer
+
+	buf.WriteString("msgid \"\"\nmsgstr \"\"\n")
+
+	for _, header := range headers {
+		buf.WriteString(Quote(header+"\n") + "\n")
+	}
+
+	for _, entry := range entries {
+		buf.WriteString("\n")
+
+		if len(entry.References) > 0 {
+			buf.WriteString("#: " + strings.Join(entry.References, " ") + "\n")
+		}
+
+		buf.WriteString("msgid " + Quote(entry.MsgID) + "\n")
+		buf.WriteString("msgstr " + Quote(entry.MsgStr) + "\n")
+	}
+
+	return buf.Bytes()
+}
+
+var escaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, "\t", `\t`, "\r", `\r`)
+
+// Quote returns the PO string literal.
+func Quote(s string) string {
+	return `"` + escaper.Replace(s) + `"`
+}
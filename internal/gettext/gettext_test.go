@@ -0,0 +1,27 @@
+package gettext_test
+
+import (
+	"testing"
+
+	"github.com/kukymbr/i18n/internal/gettext"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncode(t *testing.T) {
+	data := gettext.Encode([]string{"Language: es"}, []gettext.Entry{
+		{References: []string{"main.go:10", "main.go:12"}, MsgID: "greeting.hello", MsgStr: "¡Hola!"},
+		{MsgID: "quote", MsgStr: "Say \"hi\"\n\tto\\them"},
+	})
+
+	assert.Equal(t, `msgid ""
+msgstr ""
+"Language: es\n"
+
+#: main.go:10 main.go:12
+msgid "greeting.hello"
+msgstr "¡Hola!"
+
+msgid "quote"
+msgstr "Say \"hi\"\n\tto\\them"
+`, string(data))
+}
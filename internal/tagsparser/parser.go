@@ -4,55 +4,361 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"sync"
 )
 
-const (
-	tagI18nKey = "i18n"
-)
+// TagName is the name of the struct tag with the translation key and options.
+const TagName = "i18n"
+
+// Options are the options of the structure traversal.
+type Options struct {
+	// Collections enables updating of the string slice elements and map values.
+	// Structures inside the slices and maps are traversed regardless of this option.
+	Collections bool
+	// HookType returns true for the types, which values are passed to the HookFunc of the Parse call
+	// (the pointer to the type is checked too).
+	HookType func(t reflect.Type) bool
+}
+
+// HookFunc is called for the values of the Options.HookType types before updating them.
+// If it returns true, the value is considered handled and is not traversed.
+//
+// The walk function traverses another structure within the same Parse call, sharing the visited pointers;
+// the value being hooked is traversed by it without calling the hook again.
+type HookFunc func(v reflect.Value, walk func(inp any) error) (bool, error)
 
+// Parser updates structures, caching reflection plans of their types.
+// It is safe for the concurrent use.
+type Parser struct {
+	opts  Options
+	plans sync.Map
+}
+
+// NewParser returns a new Parser with the Options.
+func NewParser(opts Options) *Parser {
+	return &Parser{opts: opts}
+}
+
+// UpdateFunc returns the updated value for the key.
+// The data is set if the field's `i18n` tag has the `data` option.
+type UpdateFunc func(key string, data []any) string
+
+// ParseTags updates string fields of the structure using the updateValueFn with default Options.
 func ParseTags(inp any, updateValueFn func(string) string) error {
 	if updateValueFn == nil {
 		panic("updateValueFn must not be nil")
 	}
 
+	return ParseTagsWithOptions(inp, func(key string, _ []any) string {
+		return updateValueFn(key)
+	}, Options{})
+}
+
+// ParseTagsWithOptions updates the structure using a new Parser, see the Parser.Parse.
+func ParseTagsWithOptions(inp any, updateFn UpdateFunc, opts Options) error {
+	return NewParser(opts).Parse(inp, updateFn, nil)
+}
+
+// Parse recursively updates string fields of the structure using the updateFn.
+// Nested and embedded structures, pointers, slices and maps are traversed,
+// pointers already visited are skipped to avoid the infinite loops.
+// Unexported fields are skipped.
+//
+// The `i18n` tag value is used as a key if set, otherwise the field's value is used. Tag options:
+//   - prefix=errors.: the prefix added to the keys of the field, or of all the nested fields of the structure;
+//     the prefix for all the fields of a structure is set with a tag of the blank field: _ struct{} `i18n:",prefix=errors."`;
+//   - fromfield=Code: the key is the value of another field of the structure;
+//   - data=Params: another field of the structure is passed to the updateFn as the template data.
+//
+// Errors of the tags and of the hook are joined into the result.
+func (p *Parser) Parse(inp any, updateFn UpdateFunc, hook HookFunc) error {
+	if updateFn == nil {
+		panic("updateFn must not be nil")
+	}
+
+	inpValue, err := getStructPointer(inp)
+	if err != nil {
+		return err
+	}
+
+	w := p.newWalker(updateFn, hook)
+	w.walk(inpValue, target{})
+
+	return errors.Join(w.errs...)
+}
+
+func getStructPointer(inp any) (reflect.Value, error) {
 	inpType := reflect.TypeOf(inp)
+	if inpType == nil {
+		return reflect.Value{}, errors.New("expected a pointer to a struct, got nil")
+	}
+
 	if kind := inpType.Kind(); kind != reflect.Ptr {
-		return fmt.Errorf("expected a pointer to a struct, got %s", kind)
+		return reflect.Value{}, fmt.Errorf("expected a pointer to a struct, got %s", kind)
 	}
 
 	elemType := inpType.Elem()
 	if kind := elemType.Kind(); kind != reflect.Struct {
-		return fmt.Errorf("expected a pointer to a struct, got pointer to a %s", kind)
+		return reflect.Value{}, fmt.Errorf("expected a pointer to a struct, got pointer to a %s", kind)
 	}
 
 	inpValue := reflect.ValueOf(inp)
 	if inpValue.IsNil() {
-		return errors.New("got nil structure")
+		return reflect.Value{}, errors.New("got nil structure")
+	}
+
+	return inpValue, nil
+}
+
+// ParseSlice updates all the structures of the slice, see the Parse.
+// A slice of structures or of pointers to structures is expected.
+func (p *Parser) ParseSlice(inp any, updateFn UpdateFunc, hook HookFunc) error {
+	if updateFn == nil {
+		panic("updateFn must not be nil")
 	}
 
-	inpElem := inpValue.Elem()
+	inpValue := reflect.ValueOf(inp)
+	if kind := inpValue.Kind(); kind != reflect.Slice {
+		return fmt.Errorf("expected a slice of structs, got %s", kind)
+	}
 
-	for i := range elemType.NumField() {
-		field := elemType.Field(i)
+	elemType := inpValue.Type().Elem()
+	if elemType.Kind() == reflect.Ptr {
+		elemType = elemType.Elem()
+	}
+
+	if kind := elemType.Kind(); kind != reflect.Struct {
+		return fmt.Errorf("expected a slice of structs, got slice of %s", kind)
+	}
 
-		if field.Type.Kind() != reflect.String {
-			continue
+	w := p.newWalker(updateFn, hook)
+
+	for i := range inpValue.Len() {
+		w.walk(inpValue.Index(i), target{})
+	}
+
+	return errors.Join(w.errs...)
+}
+
+func (p *Parser) newWalker(updateFn UpdateFunc, hook HookFunc) *walker {
+	return &walker{
+		parser:  p,
+		update:  updateFn,
+		hook:    hook,
+		visited: make(map[visitKey]struct{}),
+		hooking: make(map[visitKey]struct{}),
+	}
+}
+
+type walker struct {
+	parser  *Parser
+	update  UpdateFunc
+	hook    HookFunc
+	visited map[visitKey]struct{}
+	// hooking are the values which hooks are in progress, they are traversed without calling the hook again.
+	hooking map[visitKey]struct{}
+	errs    []error
+	// changes is a number of the updated strings, used to put back only the changed map values.
+	changes int
+}
+
+type visitKey struct {
+	ptr uintptr
+	typ reflect.Type
+}
+
+// target describes how the traversed value is updated.
+type target struct {
+	// key is the explicit key of the value, the value itself is used as a key if empty.
+	key string
+	// prefix is added to the keys of the value and of the nested ones.
+	prefix string
+	// data is the template data of the value.
+	data []any
+	// inCollection is true for slice and map elements.
+	inCollection bool
+}
+
+func (w *walker) walk(v reflect.Value, tg target) {
+	plan := w.parser.getPlan(v.Type())
+
+	if plan.hook && w.hook != nil {
+		if handled := w.callHook(v); handled {
+			return
 		}
+	}
+
+	if plan.skip {
+		return
+	}
 
-		key := field.Tag.Get(tagI18nKey)
-		if key == "-" {
-			continue
+	//nolint:exhaustive // other kinds are not translatable
+	switch v.Kind() {
+	case reflect.String:
+		w.updateString(v, tg)
+	case reflect.Ptr:
+		w.walkPointer(v, tg)
+	case reflect.Interface:
+		if !v.IsNil() && v.Elem().Kind() == reflect.Ptr {
+			w.walkPointer(v.Elem(), tg)
 		}
+	case reflect.Struct:
+		w.walkStruct(v, plan, tg.prefix)
+	case reflect.Slice, reflect.Array:
+		for i := range v.Len() {
+			w.walk(v.Index(i), target{prefix: tg.prefix, inCollection: true})
+		}
+	case reflect.Map:
+		w.walkMap(v, tg.prefix)
+	}
+}
+
+// callHook calls the hook unless it is already in progress for the value.
+func (w *walker) callHook(v reflect.Value) bool {
+	id, addressable := getAddressKey(v)
+	if addressable {
+		if _, ok := w.hooking[id]; ok {
+			return false
+		}
+
+		w.hooking[id] = struct{}{}
+		defer delete(w.hooking, id)
+	}
+
+	handled, err := w.hook(v, w.walkNested)
+	if err != nil {
+		w.errs = append(w.errs, err)
+	}
+
+	return handled
+}
+
+// walkNested traverses the structure passed by the hook, returning the errors of its traversal.
+func (w *walker) walkNested(inp any) error {
+	inpValue, err := getStructPointer(inp)
+	if err != nil {
+		return err
+	}
 
-		valuePtr := inpElem.Field(i)
-		value := valuePtr.String()
+	errsCount := len(w.errs)
+	w.walk(inpValue, target{})
 
-		if key == "" {
-			key = value
+	err = errors.Join(w.errs[errsCount:]...)
+	w.errs = w.errs[:errsCount]
+
+	return err
+}
+
+// getAddressKey returns the key of the pointer or of the addressable value's address.
+func getAddressKey(v reflect.Value) (visitKey, bool) {
+	switch {
+	case v.Kind() == reflect.Ptr && !v.IsNil():
+		return visitKey{ptr: v.Pointer(), typ: v.Type()}, true
+	case v.CanAddr():
+		return visitKey{ptr: v.Addr().Pointer(), typ: reflect.PointerTo(v.Type())}, true
+	default:
+		return visitKey{}, false
+	}
+}
+
+func (w *walker) updateString(v reflect.Value, tg target) {
+	if !v.CanSet() || (tg.inCollection && !w.parser.opts.Collections) {
+		return
+	}
+
+	key := tg.key
+	if key == "" {
+		key = v.String()
+	}
+
+	if text := w.update(tg.prefix+key, tg.data); text != v.String() {
+		v.SetString(text)
+		w.changes++
+	}
+}
+
+func (w *walker) walkPointer(v reflect.Value, tg target) {
+	if v.IsNil() {
+		return
+	}
+
+	id := visitKey{ptr: v.Pointer(), typ: v.Type()}
+	if _, ok := w.visited[id]; ok {
+		return
+	}
+
+	w.visited[id] = struct{}{}
+
+	w.walk(v.Elem(), tg)
+}
+
+func (w *walker) walkStruct(v reflect.Value, plan *typePlan, prefix string) {
+	w.errs = append(w.errs, plan.errs...)
+
+	for _, field := range plan.fields {
+		tg := target{key: field.key, prefix: prefix + field.prefix}
+
+		if field.fromField != nil {
+			from, err := v.FieldByIndexErr(field.fromField)
+			if err != nil || !from.CanInterface() {
+				continue
+			}
+
+			if from.Kind() == reflect.String {
+				tg.key = from.String()
+			} else {
+				tg.key = fmt.Sprint(from.Interface())
+			}
+
+			if tg.key == "" {
+				continue
+			}
 		}
 
-		valuePtr.SetString(updateValueFn(key))
+		if field.dataField != nil {
+			if data, err := v.FieldByIndexErr(field.dataField); err == nil && data.CanInterface() {
+				tg.data = []any{data.Interface()}
+			}
+		}
+
+		w.walk(v.Field(field.index), tg)
+	}
+}
+
+func (w *walker) walkMap(v reflect.Value, prefix string) {
+	if v.IsNil() || !v.CanSet() {
+		return
 	}
 
-	return nil
+	tg := target{prefix: prefix, inCollection: true}
+
+	iter := v.MapRange()
+	for iter.Next() {
+		value := iter.Value()
+
+		//nolint:exhaustive // other kinds are not translatable
+		switch value.Kind() {
+		case reflect.String, reflect.Struct, reflect.Array:
+			if value.Kind() == reflect.String && !w.parser.opts.Collections {
+				continue
+			}
+
+			// Map values are not addressable, so the copy is updated and put back if changed.
+			updated := reflect.New(value.Type()).Elem()
+			updated.Set(value)
+
+			changes := w.changes
+			if w.walk(updated, tg); w.changes != changes {
+				v.SetMapIndex(iter.Key(), updated)
+			}
+		case reflect.Slice, reflect.Map:
+			// Elements are shared with the copy, so it is updated in place.
+			updated := reflect.New(value.Type()).Elem()
+			updated.Set(value)
+
+			w.walk(updated, tg)
+		default:
+			w.walk(value, tg)
+		}
+	}
 }
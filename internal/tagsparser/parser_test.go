@@ -2,6 +2,7 @@ package tagsparser_test
 
 import (
 	"strings"
+	"sync"
 	"testing"
 
 	"github.com/kukymbr/i18n/internal/tagsparser"
@@ -87,3 +88,245 @@ func TestParseTags_Panics(t *testing.T) {
 		_ = tagsparser.ParseTags(&testStruct{}, nil)
 	})
 }
+
+type nestedItem struct {
+	Title string
+	Code  string `i18n:"-"`
+}
+
+type nestedBase struct {
+	Footer string `i18n:"footer"`
+}
+
+type nestedNode struct {
+	Label string
+	Next  *nestedNode
+}
+
+type nestedStruct struct {
+	nestedBase
+
+	Header   string
+	Optional *string
+	Missing  *string
+	Item     nestedItem
+	ItemPtr  *nestedItem
+	Items    []nestedItem
+	ItemPtrs []*nestedItem
+	Tags     []string
+	Labels   map[string]string
+	ByCode   map[string]nestedItem
+	Node     *nestedNode
+	Any      any
+	private  string
+}
+
+func TestParseTagsWithOptions(t *testing.T) {
+	upper := func(key string, _ []any) string {
+		return strings.ToUpper(key)
+	}
+
+	newInput := func() *nestedStruct {
+		optional := "optional"
+		node := &nestedNode{Label: "node"}
+		node.Next = node
+
+		return &nestedStruct{
+			nestedBase: nestedBase{Footer: "value"},
+			Header:     "header",
+			Optional:   &optional,
+			Item:       nestedItem{Title: "item", Code: "code"},
+			ItemPtr:    &nestedItem{Title: "item ptr"},
+			Items:      []nestedItem{{Title: "item 1"}, {Title: "item 2"}},
+			ItemPtrs:   []*nestedItem{{Title: "item ptr 1"}, nil},
+			Tags:       []string{"tag"},
+			Labels:     map[string]string{"key": "label"},
+			ByCode:     map[string]nestedItem{"code": {Title: "by code"}},
+			Node:       node,
+			Any:        &nestedItem{Title: "any"},
+			private:    "private",
+		}
+	}
+
+	tests := []struct {
+		Name    string
+		Options tagsparser.Options
+		Tags    []string
+		Labels  map[string]string
+	}{
+		{
+			Name:   "default options",
+			Tags:   []string{"tag"},
+			Labels: map[string]string{"key": "label"},
+		},
+		{
+			Name:    "with collections",
+			Options: tagsparser.Options{Collections: true},
+			Tags:    []string{"TAG"},
+			Labels:  map[string]string{"key": "LABEL"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			val := newInput()
+
+			require.NoError(t, tagsparser.ParseTagsWithOptions(val, upper, test.Options))
+
+			assert.Equal(t, "FOOTER", val.Footer)
+			assert.Equal(t, "HEADER", val.Header)
+			assert.Equal(t, "OPTIONAL", *val.Optional)
+			assert.Nil(t, val.Missing)
+			assert.Equal(t, nestedItem{Title: "ITEM", Code: "code"}, val.Item)
+			assert.Equal(t, "ITEM PTR", val.ItemPtr.Title)
+			assert.Equal(t, []nestedItem{{Title: "ITEM 1"}, {Title: "ITEM 2"}}, val.Items)
+			assert.Equal(t, "ITEM PTR 1", val.ItemPtrs[0].Title)
+			assert.Equal(t, test.Tags, val.Tags)
+			assert.Equal(t, test.Labels, val.Labels)
+			assert.Equal(t, map[string]nestedItem{"code": {Title: "BY CODE"}}, val.ByCode)
+			assert.Equal(t, "NODE", val.Node.Label)
+			assert.Equal(t, "ANY", val.Any.(*nestedItem).Title) //nolint:forcetypeassert
+			assert.Equal(t, "private", val.private)
+		})
+	}
+}
+
+func TestParseTagsWithOptions_SharedMaps(t *testing.T) {
+	labels := map[string]string{"key": "label"}
+	byCode := map[string]nestedItem{"code": {Title: "title"}}
+
+	identity := func(key string, _ []any) string {
+		return key
+	}
+
+	var wg sync.WaitGroup
+
+	for range 4 {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			val := &nestedStruct{Labels: labels, ByCode: byCode}
+
+			assert.NoError(t, tagsparser.ParseTagsWithOptions(val, identity, tagsparser.Options{}))
+		}()
+	}
+
+	wg.Wait()
+
+	assert.Equal(t, map[string]string{"key": "label"}, labels)
+	assert.Equal(t, map[string]nestedItem{"code": {Title: "title"}}, byCode)
+}
+
+type taggedError struct {
+	_ struct{} `i18n:",prefix=errors."`
+
+	Code    int
+	Message string `i18n:",fromfield=Code"`
+	Title   string `i18n:"title,prefix=short."`
+	Details string `i18n:"details,data=Params"`
+	Params  map[string]any
+	Cause   *taggedCause `i18n:",prefix=cause."`
+	Empty   string       `i18n:",fromfield=Reason"`
+	Reason  string
+	private string
+}
+
+type taggedCause struct {
+	Message string `i18n:"message"`
+}
+
+func TestParseTagsWithOptions_Tags(t *testing.T) {
+	val := taggedError{
+		Code:    404,
+		Message: "Not found",
+		Params:  map[string]any{"Name": "Mateo"},
+		Cause:   &taggedCause{},
+		Empty:   "unchanged",
+		private: "private",
+	}
+
+	var data []any
+
+	err := tagsparser.ParseTagsWithOptions(&val, func(key string, d []any) string {
+		if d != nil {
+			data = d
+		}
+
+		return key
+	}, tagsparser.Options{})
+	require.NoError(t, err)
+
+	assert.Equal(t, "errors.404", val.Message)
+	assert.Equal(t, "errors.short.title", val.Title)
+	assert.Equal(t, "errors.details", val.Details)
+	assert.Equal(t, []any{map[string]any{"Name": "Mateo"}}, data)
+	assert.Equal(t, "errors.cause.message", val.Cause.Message)
+	assert.Equal(t, "unchanged", val.Empty)
+	assert.Equal(t, "private", val.private)
+}
+
+func TestParseTagsWithOptions_InvalidTags(t *testing.T) {
+	tests := []struct {
+		Name  string
+		Input any
+	}{
+		{
+			Name: "unknown option",
+			Input: &struct {
+				Title string `i18n:"title,unknown=1"`
+			}{},
+		},
+		{
+			Name: "key with fromfield",
+			Input: &struct {
+				Title string `i18n:"title,fromfield=Code"`
+				Code  string
+			}{},
+		},
+		{
+			Name: "unknown fromfield",
+			Input: &struct {
+				Title string `i18n:",fromfield=Code"`
+			}{},
+		},
+		{
+			Name: "unexported data",
+			Input: &struct {
+				Title  string `i18n:",data=params"`
+				params any
+			}{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			err := tagsparser.ParseTagsWithOptions(test.Input, func(key string, _ []any) string {
+				return key
+			}, tagsparser.Options{})
+
+			require.Error(t, err)
+		})
+	}
+}
+
+func BenchmarkParser_Parse(b *testing.B) {
+	update := func(key string, _ []any) string {
+		return key
+	}
+
+	b.Run("cached plans", func(b *testing.B) {
+		parser := tagsparser.NewParser(tagsparser.Options{})
+
+		for b.Loop() {
+			_ = parser.Parse(&taggedError{Code: 404, Cause: &taggedCause{}}, update, nil)
+		}
+	})
+
+	b.Run("new plans", func(b *testing.B) {
+		for b.Loop() {
+			_ = tagsparser.ParseTagsWithOptions(&taggedError{Code: 404, Cause: &taggedCause{}}, update, tagsparser.Options{})
+		}
+	})
+}
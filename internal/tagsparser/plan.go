@@ -0,0 +1,146 @@
+package tagsparser
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// typePlan is a cached result of the type reflection.
+type typePlan struct {
+	// hook is true if the HookFunc should be called for the values of the type.
+	hook bool
+	// skip is true if values of the type could not contain strings.
+	skip bool
+	// prefix is the structure-level key prefix.
+	prefix string
+	fields []fieldPlan
+	// errs are errors of the structure tags, reported on every traversal.
+	errs []error
+}
+
+type fieldPlan struct {
+	index  int
+	key    string
+	prefix string
+	// fromField and dataField are the index paths of the fields referenced by the tag, nil if not set.
+	fromField []int
+	dataField []int
+}
+
+// getPlan returns the cached plan of the type, creating it on the first call.
+func (p *Parser) getPlan(t reflect.Type) *typePlan {
+	if plan, ok := p.plans.Load(t); ok {
+		return plan.(*typePlan) //nolint:forcetypeassert // only plans are stored
+	}
+
+	plan, _ := p.plans.LoadOrStore(t, p.newPlan(t))
+
+	return plan.(*typePlan) //nolint:forcetypeassert // only plans are stored
+}
+
+func (p *Parser) newPlan(t reflect.Type) *typePlan {
+	plan := &typePlan{}
+	if p.opts.HookType != nil {
+		plan.hook = p.opts.HookType(t) || p.opts.HookType(reflect.PointerTo(t))
+	}
+
+	//nolint:exhaustive // the rest kinds could contain strings
+	switch t.Kind() {
+	case reflect.Bool, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64, reflect.Complex64, reflect.Complex128,
+		reflect.Chan, reflect.Func, reflect.UnsafePointer:
+		plan.skip = !plan.hook
+	case reflect.Struct:
+		planStruct(t, plan)
+	}
+
+	return plan
+}
+
+func planStruct(t reflect.Type, plan *typePlan) {
+	if field, ok := t.FieldByName("_"); ok {
+		tag, err := ParseTag(field.Tag.Get(TagName))
+		if err != nil {
+			plan.errs = append(plan.errs, fmt.Errorf("%s: %w", t, err))
+		}
+
+		plan.prefix = tag.Prefix
+	}
+
+	// Fields used as keys or data of other fields are not translated.
+	referenced := make(map[string]struct{})
+
+	for i := range t.NumField() {
+		field := t.Field(i)
+
+		// Exported fields of the unexported embedded structures are still settable.
+		if !field.IsExported() && !field.Anonymous {
+			continue
+		}
+
+		tag, err := ParseTag(field.Tag.Get(TagName))
+		if err == nil {
+			err = planField(t, tag, plan, i)
+		}
+
+		if err != nil {
+			plan.errs = append(plan.errs, fmt.Errorf("%s.%s: %w", t, field.Name, err))
+
+			continue
+		}
+
+		if tag.FromField != "" {
+			referenced[tag.FromField] = struct{}{}
+		}
+
+		if tag.DataField != "" {
+			referenced[tag.DataField] = struct{}{}
+		}
+	}
+
+	fields := plan.fields[:0]
+
+	for _, f := range plan.fields {
+		if _, ok := referenced[t.Field(f.index).Name]; !ok {
+			fields = append(fields, f)
+		}
+	}
+
+	plan.fields = fields
+}
+
+func planField(t reflect.Type, tag Tag, plan *typePlan, index int) error {
+	if tag.Skip {
+		return nil
+	}
+
+	f := fieldPlan{index: index, key: tag.Key, prefix: plan.prefix + tag.Prefix}
+
+	var err error
+
+	if tag.FromField != "" {
+		if f.fromField, err = getFieldIndex(t, tag.FromField); err != nil {
+			return fmt.Errorf("fromfield %w", err)
+		}
+	}
+
+	if tag.DataField != "" {
+		if f.dataField, err = getFieldIndex(t, tag.DataField); err != nil {
+			return fmt.Errorf("data %w", err)
+		}
+	}
+
+	plan.fields = append(plan.fields, f)
+
+	return nil
+}
+
+func getFieldIndex(t reflect.Type, name string) ([]int, error) {
+	field, ok := t.FieldByName(name)
+	if !ok || !field.IsExported() {
+		return nil, fmt.Errorf("%s: no such exported field", name)
+	}
+
+	return field.Index, nil
+}
@@ -0,0 +1,46 @@
+package tagsparser
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Tag is a parsed `i18n` tag: `i18n:"key,prefix=errors.,fromfield=Code,data=Params"`.
+type Tag struct {
+	Key       string
+	Skip      bool
+	Prefix    string
+	FromField string
+	DataField string
+}
+
+// ParseTag parses the `i18n` tag value, see the Parser.Parse for the options.
+func ParseTag(tag string) (Tag, error) {
+	if tag == "-" {
+		return Tag{Skip: true}, nil
+	}
+
+	parts := strings.Split(tag, ",")
+	parsed := Tag{Key: parts[0]}
+
+	for _, part := range parts[1:] {
+		name, value, _ := strings.Cut(part, "=")
+
+		switch name {
+		case "prefix":
+			parsed.Prefix = value
+		case "fromfield":
+			parsed.FromField = value
+		case "data":
+			parsed.DataField = value
+		default:
+			return Tag{}, fmt.Errorf("unknown i18n tag option %q", name)
+		}
+	}
+
+	if parsed.Key != "" && parsed.FromField != "" {
+		return Tag{}, fmt.Errorf("i18n tag %q: key and fromfield are mutually exclusive", tag)
+	}
+
+	return parsed, nil
+}
@@ -0,0 +1,141 @@
+package i18n
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Priorities of the common translation layers.
+// Translations from a layer with a higher priority override the ones from the lower layers.
+const (
+	PriorityBase       = 0
+	PriorityWhiteLabel = 100
+	PriorityTenant     = 200
+	PriorityRuntime    = 300
+)
+
+// DefaultLayer is a name of the layer receiving translations from the sources not wrapped with the InLayer.
+const DefaultLayer = "default"
+
+// Origin describes where the translation came from.
+type Origin struct {
+	Position `yaml:",inline"`
+
+	Layer    string `json:"layer" yaml:"layer"`
+	Priority int    `json:"priority" yaml:"priority"`
+}
+
+// InLayer loads translations from the sources into the named layer with the given priority.
+// When several layers define the same key, the layer with the highest priority wins;
+// for layers with equal priorities, the last added one wins.
+func InLayer(name string, priority int, sources ...BundleSource) BundleSource {
+	return func(b *Bundle) error {
+		l, err := b.getOrCreateLayer(name, priority)
+		if err != nil {
+			return err
+		}
+
+		prev := b.loading
+		b.loading = l
+
+		defer func() {
+			b.loading = prev
+		}()
+
+		for _, source := range sources {
+			if err := source(b); err != nil {
+				return fmt.Errorf("layer %s: %w", name, err)
+			}
+		}
+
+		return nil
+	}
+}
+
+type layer struct {
+	name         string
+	priority     int
+	order        int
+	translations map[Tag]Translations
+	positions    map[Tag]map[string]Position
+}
+
+func newLayer(name string, priority int, order int) *layer {
+	return &layer{
+		name:         name,
+		priority:     priority,
+		order:        order,
+		translations: make(map[Tag]Translations),
+		positions:    make(map[Tag]map[string]Position),
+	}
+}
+
+func (l *layer) set(lang Tag, key string, text string, pos Position) {
+	setTranslation(l.translations, lang, key, text)
+
+	if _, ok := l.positions[lang]; !ok {
+		l.positions[lang] = make(map[string]Position)
+	}
+
+	l.positions[lang][key] = pos
+}
+
+func (l *layer) origin(lang Tag, key string) Origin {
+	return Origin{
+		Position: l.positions[lang][key],
+		Layer:    l.name,
+		Priority: l.priority,
+	}
+}
+
+// findDuplicates returns keys of the other layer, already defined in the layer l.
+func (l *layer) findDuplicates(other *layer) []*DuplicateKeyError {
+	var found []*DuplicateKeyError
+
+	for lang, translations := range other.translations {
+		for _, key := range getSortedKeys(translations, strings.Compare) {
+			if _, ok := l.translations[lang][key]; !ok {
+				continue
+			}
+
+			found = append(found, &DuplicateKeyError{
+				Language:    lang,
+				Key:         key,
+				Layer:       l.name,
+				Definitions: []Position{l.positions[lang][key], other.positions[lang][key]},
+			})
+		}
+	}
+
+	return found
+}
+
+// overrides returns true if translations from the layer l take precedence over the other layer's ones.
+func (l *layer) overrides(other *layer) bool {
+	if l.priority != other.priority {
+		return l.priority > other.priority
+	}
+
+	return l.order >= other.order
+}
+
+func compareLayers(a *layer, b *layer) int {
+	if c := cmp.Compare(a.priority, b.priority); c != 0 {
+		return c
+	}
+
+	return cmp.Compare(a.order, b.order)
+}
+
+func sortLayers(layers map[string]*layer) []*layer {
+	sorted := make([]*layer, 0, len(layers))
+	for _, l := range layers {
+		sorted = append(sorted, l)
+	}
+
+	slices.SortFunc(sorted, compareLayers)
+
+	return sorted
+}
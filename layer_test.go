@@ -0,0 +1,86 @@
+package i18n_test
+
+import (
+	"testing"
+
+	"github.com/kukymbr/i18n"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBundle_Layers(t *testing.T) {
+	bundle, err := i18n.NewBundle(
+		i18n.English,
+		i18n.InLayer("tenant", i18n.PriorityTenant,
+			i18n.FromString(i18n.JSON, `{"translations": {"title": "Tenant title"}}`),
+		),
+		i18n.FromString(i18n.JSON, `{"translations": {"title": "Base title", "subtitle": "Base subtitle"}}`),
+		i18n.InLayer("white_label", i18n.PriorityWhiteLabel,
+			i18n.FromString(i18n.JSON, `{"translations": {"title": "Brand title", "subtitle": "Brand subtitle"}}`),
+		),
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{i18n.DefaultLayer, "white_label", "tenant"}, bundle.GetLayers())
+
+	assert.Equal(t, "Tenant title", bundle.T(i18n.English, "title"))
+	assert.Equal(t, "Brand subtitle", bundle.T(i18n.English, "subtitle"))
+
+	origin, ok := bundle.GetTranslationOrigin(i18n.English, "title")
+	require.True(t, ok)
+	assert.Equal(t, "tenant", origin.Layer)
+	assert.Equal(t, i18n.PriorityTenant, origin.Priority)
+
+	_, ok = bundle.GetTranslationOrigin(i18n.English, "unknown")
+	assert.False(t, ok)
+
+	hash := bundle.CalcHash()
+
+	t.Run("add runtime layer", func(t *testing.T) {
+		err := bundle.AddLayer("patch", i18n.PriorityRuntime,
+			i18n.FromString(i18n.JSON, `{"translations": {"subtitle": "Patched subtitle"}}`),
+		)
+		require.NoError(t, err)
+
+		assert.Equal(t, "Patched subtitle", bundle.T(i18n.English, "subtitle"))
+		assert.NotEqual(t, hash, bundle.CalcHash())
+	})
+
+	t.Run("remove layers", func(t *testing.T) {
+		assert.True(t, bundle.RemoveLayer("patch"))
+		assert.Equal(t, hash, bundle.CalcHash())
+
+		assert.True(t, bundle.RemoveLayer("tenant"))
+		assert.Equal(t, "Brand title", bundle.T(i18n.English, "title"))
+
+		assert.True(t, bundle.RemoveLayer("white_label"))
+		assert.Equal(t, "Base title", bundle.T(i18n.English, "title"))
+		assert.Equal(t, "Base subtitle", bundle.T(i18n.English, "subtitle"))
+
+		origin, ok := bundle.GetTranslationOrigin(i18n.Und, "title")
+		require.True(t, ok)
+		assert.Equal(t, i18n.DefaultLayer, origin.Layer)
+
+		assert.False(t, bundle.RemoveLayer("unknown"))
+	})
+}
+
+func TestBundle_LayersNegativeCases(t *testing.T) {
+	_, err := i18n.NewBundle(
+		i18n.English,
+		i18n.InLayer("test", 1),
+		i18n.InLayer("test", 2),
+	)
+	require.Error(t, err)
+
+	_, err = i18n.NewBundle(
+		i18n.English,
+		i18n.InLayer("test", 1, i18n.FromString(i18n.JSON, "{{ some broken JSON }}")),
+	)
+	require.Error(t, err)
+
+	bundle := i18n.NewEmptyBundle()
+
+	require.Error(t, bundle.AddLayer("test", 1, i18n.FromString(i18n.JSON, "{{ some broken JSON }}")))
+	assert.Empty(t, bundle.GetLayers())
+}
@@ -0,0 +1,384 @@
+package i18n
+
+import (
+	"container/list"
+	"errors"
+	"slices"
+	"sync"
+)
+
+// WithLazyLoading enables the lazy loading of the translation files.
+// Files with a language in their names (`<name>.<lang>.<ext>`, `<lang>.<ext>` or `<lang>/<name>.<ext>`)
+// are only registered by the FromDirs, FromFiles and FromEmbeddedFS sources
+// and parsed on the first translation to their language.
+// The language declared inside the file should match the one from its name.
+// Files named with the three-letter only language codes, except the fallback language's one, are loaded eagerly.
+//
+// If the maxLanguages is greater than zero, the least recently used languages are unloaded
+// when the number of loaded lazy languages exceeds it (the fallback language is never unloaded).
+//
+// Broken lazy files do not make the NewBundle fail: their errors are passed to the error handler
+// (see WithErrorHandler) when they are loaded, unless the WithValidation is set,
+// which loads all the lazy files while creating the bundle and fails on their errors.
+//
+// Note that the CalcHash and bundle exports load all the registered languages.
+func WithLazyLoading(maxLanguages int) BundleSource {
+	return func(b *Bundle) error {
+		b.lazy = newLazyLoader(maxLanguages)
+
+		return nil
+	}
+}
+
+type lazyFile struct {
+	ref      fileRef
+	layer    *layer
+	loadedAs Tag
+}
+
+type lazyLoader struct {
+	limit int
+
+	mu         sync.Mutex
+	pending    map[Tag][]*lazyFile
+	loaded     map[Tag][]*lazyFile
+	inProgress map[Tag]chan struct{}
+	// pins are the numbers of the readers of the languages, pinned languages are not evicted.
+	pins       map[Tag]int
+	recents    *list.List
+	recentsIdx map[Tag]*list.Element
+}
+
+func newLazyLoader(limit int) *lazyLoader {
+	return &lazyLoader{
+		limit:      limit,
+		pending:    make(map[Tag][]*lazyFile),
+		loaded:     make(map[Tag][]*lazyFile),
+		inProgress: make(map[Tag]chan struct{}),
+		pins:       make(map[Tag]int),
+		recents:    list.New(),
+		recentsIdx: make(map[Tag]*list.Element),
+	}
+}
+
+func (lz *lazyLoader) register(lang Tag, file *lazyFile) {
+	lz.mu.Lock()
+	defer lz.mu.Unlock()
+
+	lz.pending[lang] = append(lz.pending[lang], file)
+}
+
+// acquire pins the language and takes its pending files to load them.
+// Waits if the language is being loaded or unloaded by another goroutine.
+func (lz *lazyLoader) acquire(lang Tag) []*lazyFile {
+	lz.mu.Lock()
+	defer lz.mu.Unlock()
+
+	for {
+		ch, ok := lz.inProgress[lang]
+		if !ok {
+			break
+		}
+
+		lz.mu.Unlock()
+		<-ch
+		lz.mu.Lock()
+	}
+
+	lz.pins[lang]++
+	lz.touch(lang)
+
+	files := lz.pending[lang]
+	if len(files) == 0 {
+		return nil
+	}
+
+	delete(lz.pending, lang)
+	lz.inProgress[lang] = make(chan struct{})
+
+	return files
+}
+
+func (lz *lazyLoader) release(lang Tag, files []*lazyFile) {
+	lz.mu.Lock()
+	defer lz.mu.Unlock()
+
+	lz.loaded[lang] = append(lz.loaded[lang], files...)
+	lz.touch(lang)
+
+	close(lz.inProgress[lang])
+	delete(lz.inProgress, lang)
+}
+
+func (lz *lazyLoader) unpin(lang Tag) {
+	lz.mu.Lock()
+	defer lz.mu.Unlock()
+
+	if lz.pins[lang]--; lz.pins[lang] <= 0 {
+		delete(lz.pins, lang)
+	}
+}
+
+func (lz *lazyLoader) getPendingLanguages() []Tag {
+	lz.mu.Lock()
+	defer lz.mu.Unlock()
+
+	return getSortedKeys(lz.pending, compareTags)
+}
+
+func (lz *lazyLoader) touch(lang Tag) {
+	if _, ok := lz.loaded[lang]; !ok {
+		return
+	}
+
+	if elem, ok := lz.recentsIdx[lang]; ok {
+		lz.recents.MoveToFront(elem)
+
+		return
+	}
+
+	lz.recentsIdx[lang] = lz.recents.PushFront(lang)
+}
+
+// isComplete returns true if all the registered files are loaded.
+func (lz *lazyLoader) isComplete() bool {
+	lz.mu.Lock()
+	defer lz.mu.Unlock()
+
+	return len(lz.pending) == 0 && len(lz.inProgress) == 0
+}
+
+// removeLayer drops the pending and loaded files of the removed layer.
+func (lz *lazyLoader) removeLayer(l *layer) {
+	lz.mu.Lock()
+	defer lz.mu.Unlock()
+
+	isRemoved := func(f *lazyFile) bool {
+		return f.layer == l
+	}
+
+	for lang, files := range lz.pending {
+		if lz.pending[lang] = slices.DeleteFunc(files, isRemoved); len(lz.pending[lang]) == 0 {
+			delete(lz.pending, lang)
+		}
+	}
+
+	for lang, files := range lz.loaded {
+		lz.loaded[lang] = slices.DeleteFunc(files, isRemoved)
+	}
+}
+
+// evict unloads the least recently used languages exceeding the limit
+// and returns their files back to the pending ones.
+// The fallback language is not counted and never evicted, as well as the pinned ones.
+func (lz *lazyLoader) evict(fallback Tag, unload func(files []*lazyFile)) {
+	if lz.limit < 1 {
+		return
+	}
+
+	evicted := lz.takeEvicted(fallback)
+
+	for lang, files := range evicted {
+		unload(files)
+
+		lz.mu.Lock()
+		lz.pending[lang] = append(lz.pending[lang], files...)
+		close(lz.inProgress[lang])
+		delete(lz.inProgress, lang)
+		lz.mu.Unlock()
+	}
+}
+
+// takeEvicted marks the least recently used languages exceeding the limit as being unloaded.
+func (lz *lazyLoader) takeEvicted(fallback Tag) map[Tag][]*lazyFile {
+	lz.mu.Lock()
+	defer lz.mu.Unlock()
+
+	var evicted map[Tag][]*lazyFile
+
+	count := len(lz.loaded)
+	if _, ok := lz.loaded[fallback]; ok {
+		count--
+	}
+
+	for elem := lz.recents.Back(); elem != nil && count > lz.limit; {
+		prev := elem.Prev()
+
+		lang, _ := elem.Value.(Tag)
+		_, busy := lz.inProgress[lang]
+
+		if lang != fallback && lz.pins[lang] == 0 && !busy {
+			count--
+
+			if evicted == nil {
+				evicted = make(map[Tag][]*lazyFile)
+			}
+
+			evicted[lang] = lz.loaded[lang]
+
+			delete(lz.loaded, lang)
+			delete(lz.recentsIdx, lang)
+			lz.recents.Remove(elem)
+			lz.inProgress[lang] = make(chan struct{})
+		}
+
+		elem = prev
+	}
+
+	return evicted
+}
+
+// addFileRefs reads the files into the bundle or registers them for the lazy loading.
+func (b *Bundle) addFileRefs(refs []fileRef) error {
+	errs := make([]error, 0, len(refs))
+
+	for _, ref := range refs {
+		if b.lazy != nil {
+			if lang, ok := ref.inferLanguage(b.fallbackLanguage); ok {
+				b.lazy.register(lang, &lazyFile{ref: ref, layer: b.getLoadingLayer()})
+
+				continue
+			}
+		}
+
+		file, err := ref.read()
+		if err != nil {
+			errs = append(errs, err)
+
+			continue
+		}
+
+		b.addFile(file)
+	}
+
+	return errors.Join(errs...)
+}
+
+// ensureLanguage loads the pending lazy files of the language and pins it,
+// so it is not evicted until the returned function is called.
+func (b *Bundle) ensureLanguage(lang Tag) (release func()) {
+	releaseParent := func() {}
+	if b.parent != nil {
+		releaseParent = b.parent.ensureLanguage(lang)
+	}
+
+	if b.lazy == nil {
+		return releaseParent
+	}
+
+	if files := b.lazy.acquire(lang); files != nil {
+		errs, warnings := b.loadLazyFiles(files)
+		b.lazy.release(lang, files)
+		b.precompileTemplates(lang)
+
+		// Handled after the loading is done, so the handler could use the bundle.
+		b.handleErrors(append(errs, warnings...))
+	}
+
+	b.lazy.evict(b.fallbackLanguage, b.unloadLazyFiles)
+
+	return func() {
+		b.lazy.unpin(lang)
+		releaseParent()
+	}
+}
+
+// ensureAllLanguages loads all the pending lazy files, passing their errors to the handleError.
+func (b *Bundle) ensureAllLanguages() {
+	b.handleErrors(b.loadAllLanguages())
+}
+
+// loadAllLanguages loads all the pending lazy files and returns their errors,
+// the warnings are passed to the handleError.
+func (b *Bundle) loadAllLanguages() []error {
+	var errs []error
+
+	if b.parent != nil {
+		errs = b.parent.loadAllLanguages()
+	}
+
+	if b.lazy == nil {
+		return errs
+	}
+
+	for _, lang := range b.lazy.getPendingLanguages() {
+		if files := b.lazy.acquire(lang); files != nil {
+			loadErrs, warnings := b.loadLazyFiles(files)
+			b.lazy.release(lang, files)
+			b.handleErrors(warnings)
+
+			errs = append(errs, loadErrs...)
+		}
+
+		b.lazy.unpin(lang)
+	}
+
+	return errs
+}
+
+// loadLazyFiles adds the files to the bundle, returning the errors and the warnings for the handleError.
+func (b *Bundle) loadLazyFiles(files []*lazyFile) ([]error, []error) {
+	var errs []error
+
+	parsed := make([]*translationsFile, len(files))
+
+	for i, f := range files {
+		file, err := f.ref.read()
+		if err != nil {
+			errs = append(errs, err)
+
+			continue
+		}
+
+		parsed[i] = file
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	duplicatesCount := len(b.duplicates)
+
+	for i, file := range parsed {
+		// The layer could be removed while the file was read.
+		if file == nil || b.layers[files[i].layer.name] != files[i].layer {
+			continue
+		}
+
+		b.loading = files[i].layer
+		b.addFile(file)
+		b.loading = nil
+
+		files[i].loadedAs = file.lang
+		if file.lang == Und {
+			files[i].loadedAs = b.fallbackLanguage
+		}
+	}
+
+	if err := b.checkDuplicates(b.duplicates[duplicatesCount:]); err != nil {
+		errs = append(errs, err)
+	}
+
+	return errs, b.takeWarnings()
+}
+
+// unloadLazyFiles removes the files' translations and templates of their languages.
+func (b *Bundle) unloadLazyFiles(files []*lazyFile) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for _, f := range files {
+		positions := f.layer.positions[f.loadedAs]
+
+		for key, pos := range positions {
+			if pos.Path != f.ref.path {
+				continue
+			}
+
+			delete(positions, key)
+			delete(f.layer.translations[f.loadedAs], key)
+			b.remerge(f.loadedAs, key)
+		}
+
+		b.templates.resetLanguage(f.loadedAs)
+	}
+}
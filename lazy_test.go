@@ -0,0 +1,205 @@
+package i18n_test
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/kukymbr/i18n"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBundle_LazyLoading(t *testing.T) {
+	dir := t.TempDir()
+
+	writeTestFile(t, dir, "app.en.yaml", "language: en\ntranslations: {title: Title, subtitle: Subtitle}")
+	writeTestFile(t, dir, "app.es.yaml", "language: es\ntranslations: {title: Título}")
+	writeTestFile(t, dir, "de/messages.yaml", "language: de\ntranslations: {title: Titel}")
+	writeTestFile(t, dir, "app.fr.yaml", "language: fr\ntranslations: [not, a, map]")
+	writeTestFile(t, dir, "common.yaml", "translations: {common: Common}")
+	writeTestFile(t, dir, "app.yaml", "language: es\ntranslations: {app: Aplicación}")
+
+	var errs []error
+
+	bundle, err := i18n.NewBundle(
+		i18n.English,
+		i18n.WithLazyLoading(1),
+		i18n.WithErrorHandler(func(err error) {
+			errs = append(errs, err)
+		}),
+		i18n.FromDirs(i18n.YAML, true, dir),
+	)
+	require.NoError(t, err, "files are not parsed while creating the bundle")
+
+	assert.Equal(t, "Common", bundle.T(i18n.Spanish, "common"))
+	assert.Equal(t, "Título", bundle.T(i18n.Spanish, "title"))
+	assert.Equal(t, "Subtitle", bundle.T(i18n.Spanish, "subtitle"))
+	assert.Equal(t, "Aplicación", bundle.T(i18n.Spanish, "app"), "app is not a language of the file")
+	assert.Equal(t, "Titel", bundle.T(i18n.German, "title"))
+	assert.Empty(t, errs)
+
+	t.Run("when file is broken", func(t *testing.T) {
+		assert.Equal(t, "Title", bundle.T(i18n.French, "title"))
+		require.Len(t, errs, 1)
+		assert.Len(t, i18n.GetLoadErrors(errs[0]), 1)
+	})
+
+	t.Run("when language is evicted", func(t *testing.T) {
+		writeTestFile(t, dir, "app.es.yaml", "language: es\ntranslations: {title: Título nuevo}")
+
+		assert.Equal(t, "Titel", bundle.T(i18n.German, "title"))
+		assert.Equal(t, "Título nuevo", bundle.T(i18n.Spanish, "title"))
+	})
+
+	t.Run("hash and export", func(t *testing.T) {
+		eager, err := i18n.NewBundle(
+			i18n.English,
+			i18n.WithErrorHandler(func(error) {}),
+			i18n.FromDirs(i18n.YAML, true, dir),
+		)
+		require.Error(t, err)
+		assert.Nil(t, eager)
+
+		export := bundle.GetBundleExport()
+
+		require.Len(t, export.Languages, 3)
+		assert.Equal(t, i18n.German, export.Languages[0].Language)
+		assert.Equal(t, i18n.English, export.Languages[1].Language)
+		assert.Equal(t, i18n.Spanish, export.Languages[2].Language)
+		assert.Equal(t, bundle.CalcHash(), export.ETag)
+	})
+}
+
+func TestBundle_LazyLoading_WithValidation(t *testing.T) {
+	dir := t.TempDir()
+
+	writeTestFile(t, dir, "app.en.yaml", "language: en\ntranslations: {title: Title}")
+	writeTestFile(t, dir, "app.es.yaml", "language: es\ntranslations: {title: 'Título, {{ .Name '}")
+	writeTestFile(t, dir, "app.fr.yaml", "language: fr\ntranslations: [not, a, map]")
+
+	bundle, err := i18n.NewBundle(i18n.English, i18n.WithLazyLoading(0), i18n.FromDirs(i18n.YAML, true, dir))
+	require.NoError(t, err, "lazy files are not loaded without the validation")
+	assert.NotNil(t, bundle)
+
+	_, err = i18n.NewBundle(
+		i18n.English,
+		i18n.WithLazyLoading(0),
+		i18n.WithValidation(),
+		i18n.FromDirs(i18n.YAML, true, dir),
+	)
+	require.Error(t, err)
+	require.Len(t, i18n.GetLoadErrors(err), 1)
+	assert.Contains(t, i18n.GetLoadErrors(err)[0].Path, "app.fr.yaml")
+
+	writeTestFile(t, dir, "app.fr.yaml", "language: fr\ntranslations: {title: Titre}")
+
+	_, err = i18n.NewBundle(
+		i18n.English,
+		i18n.WithLazyLoading(0),
+		i18n.WithValidation(),
+		i18n.FromDirs(i18n.YAML, true, dir),
+	)
+	require.Len(t, i18n.GetTemplateErrors(err), 1)
+	assert.Equal(t, i18n.Spanish, i18n.GetTemplateErrors(err)[0].Language)
+}
+
+func TestBundle_LazyLoading_RemoveLayer(t *testing.T) {
+	dir := t.TempDir()
+
+	writeTestFile(t, dir, "base/app.es.yaml", "language: es\ntranslations: {title: Título}")
+	writeTestFile(t, dir, "brand/app.es.yaml", "language: es\ntranslations: {title: Título de marca}")
+	writeTestFile(t, dir, "brand/app.de.yaml", "language: de\ntranslations: {title: Markentitel}")
+
+	bundle, err := i18n.NewBundle(
+		i18n.English,
+		i18n.WithLazyLoading(0),
+		i18n.FromDirs(i18n.YAML, true, filepath.Join(dir, "base")),
+		i18n.InLayer("wl", i18n.PriorityWhiteLabel, i18n.FromDirs(i18n.YAML, true, filepath.Join(dir, "brand"))),
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t, "Markentitel", bundle.T(i18n.German, "title"))
+	assert.True(t, bundle.RemoveLayer("wl"))
+
+	assert.Equal(t, "Título", bundle.T(i18n.Spanish, "title"))
+	assert.Equal(t, "title", bundle.T(i18n.German, "title"))
+
+	origin, ok := bundle.GetTranslationOrigin(i18n.Spanish, "title")
+	require.True(t, ok)
+	assert.Equal(t, i18n.DefaultLayer, origin.Layer)
+}
+
+func TestBundle_LazyLoading_ReentrantErrorHandler(t *testing.T) {
+	dir := t.TempDir()
+
+	writeTestFile(t, dir, "first.es.yaml", "language: es\ntranslations: {title: Título}")
+	writeTestFile(t, dir, "second.es.yaml", "language: es\ntranslations: {title: Otro título}")
+
+	var (
+		bundle   *i18n.Bundle
+		warnings []string
+	)
+
+	bundle, err := i18n.NewBundle(
+		i18n.English,
+		i18n.WithLazyLoading(0),
+		i18n.WithDuplicatePolicy(i18n.DuplicatesWarn),
+		i18n.WithErrorHandler(func(err error) {
+			warnings = append(warnings, bundle.T(i18n.Spanish, "title"))
+			assert.Len(t, bundle.GetDuplicates(), 1)
+		}),
+		i18n.FromDirs(i18n.YAML, true, dir),
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t, "Otro título", bundle.T(i18n.Spanish, "title"))
+	assert.Equal(t, []string{"Otro título"}, warnings)
+}
+
+func TestBundle_LazyLoading_Concurrent(t *testing.T) {
+	dir := t.TempDir()
+
+	expected := map[i18n.Tag]string{i18n.Spanish: "Título", i18n.German: "Titel", i18n.French: "Titre"}
+
+	writeTestFile(t, dir, "app.en.yaml", "language: en\ntranslations: {title: Title}")
+
+	for lang, title := range expected {
+		writeTestFile(t, dir, "app."+lang.String()+".yaml", "language: "+lang.String()+"\ntranslations: {title: "+title+"}")
+	}
+
+	bundle, err := i18n.NewBundle(i18n.English, i18n.WithLazyLoading(1), i18n.FromDirs(i18n.YAML, true, dir))
+	require.NoError(t, err)
+
+	var wg sync.WaitGroup
+
+	for range 8 {
+		for lang, title := range expected {
+			wg.Add(1)
+
+			go func() {
+				defer wg.Done()
+
+				for range 500 {
+					if got := bundle.T(lang, "title"); got != title {
+						assert.Equal(t, title, got)
+
+						return
+					}
+				}
+			}()
+		}
+	}
+
+	wg.Wait()
+}
+
+func writeTestFile(t *testing.T, dir string, name string, content string) {
+	t.Helper()
+
+	path := filepath.Join(dir, name)
+
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+}
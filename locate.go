@@ -0,0 +1,262 @@
+package i18n
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Position is a position of the translation definition in the source.
+type Position struct {
+	Path   string `json:"path,omitempty" yaml:"path,omitempty"`
+	Line   int    `json:"line,omitempty" yaml:"line,omitempty"`
+	Column int    `json:"column,omitempty" yaml:"column,omitempty"`
+}
+
+// String returns the position in a `path:line:column` format.
+func (p Position) String() string {
+	path := p.Path
+	if path == "" {
+		path = "<input>"
+	}
+
+	if p.Line == 0 {
+		return path
+	}
+
+	if p.Column == 0 {
+		return path + ":" + strconv.Itoa(p.Line)
+	}
+
+	return path + ":" + strconv.Itoa(p.Line) + ":" + strconv.Itoa(p.Column)
+}
+
+func (p Position) isKnown() bool {
+	return p.Line > 0
+}
+
+// keyLocatorFunc finds positions of the translation keys in the raw input.
+// Positions are mapped by the raw keys path (see joinRawPath) in the document order.
+type keyLocatorFunc func(data []byte) (map[string][]Position, error)
+
+var keyLocators = map[DataType]keyLocatorFunc{
+	YAML: locateYAMLKeys,
+	JSON: locateJSONKeys,
+}
+
+func locateKeys(dataType DataType, data []byte) map[string][]Position {
+	dataTypeMu.RLock()
+	fn, ok := keyLocators[dataType]
+	dataTypeMu.RUnlock()
+
+	if !ok {
+		return nil
+	}
+
+	positions, err := fn(data)
+	if err != nil {
+		return nil
+	}
+
+	return positions
+}
+
+func joinRawPath(segments []string) string {
+	return strings.Join(segments, "\x1f")
+}
+
+func locateYAMLKeys(data []byte) (map[string][]Position, error) {
+	var doc yaml.Node
+
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+
+	positions := make(map[string][]Position)
+
+	if len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		return positions, nil
+	}
+
+	root := doc.Content[0]
+
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		if root.Content[i].Value == "translations" {
+			walkYAMLKeys(nil, root.Content[i+1], positions)
+		}
+	}
+
+	return positions, nil
+}
+
+func walkYAMLKeys(parent []string, node *yaml.Node, positions map[string][]Position) {
+	if node.Kind != yaml.MappingNode {
+		return
+	}
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		keyNode, valueNode := node.Content[i], node.Content[i+1]
+		segments := append(parent[:len(parent):len(parent)], keyNode.Value)
+
+		if valueNode.Kind == yaml.MappingNode {
+			walkYAMLKeys(segments, valueNode, positions)
+
+			continue
+		}
+
+		raw := joinRawPath(segments)
+		positions[raw] = append(positions[raw], Position{Line: keyNode.Line, Column: keyNode.Column})
+	}
+}
+
+func locateJSONKeys(data []byte) (map[string][]Position, error) {
+	loc := &jsonLocator{
+		data:      data,
+		dec:       json.NewDecoder(bytes.NewReader(data)),
+		positions: make(map[string][]Position),
+	}
+
+	tok, err := loc.dec.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	if tok != json.Delim('{') {
+		return loc.positions, nil
+	}
+
+	for loc.dec.More() {
+		key, _, err := loc.readKey()
+		if err != nil {
+			return nil, err
+		}
+
+		if key != "translations" {
+			if err := loc.skipValue(); err != nil {
+				return nil, err
+			}
+
+			continue
+		}
+
+		if err := loc.walk(nil); err != nil {
+			return nil, err
+		}
+	}
+
+	return loc.positions, nil
+}
+
+type jsonLocator struct {
+	data      []byte
+	dec       *json.Decoder
+	positions map[string][]Position
+}
+
+func (loc *jsonLocator) walk(parent []string) error {
+	tok, err := loc.dec.Token()
+	if err != nil {
+		return err
+	}
+
+	if tok != json.Delim('{') {
+		return nil
+	}
+
+	for loc.dec.More() {
+		key, pos, err := loc.readKey()
+		if err != nil {
+			return err
+		}
+
+		segments := append(parent[:len(parent):len(parent)], key)
+
+		if loc.nextIsObject() {
+			if err := loc.walk(segments); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		if err := loc.skipValue(); err != nil {
+			return err
+		}
+
+		raw := joinRawPath(segments)
+		loc.positions[raw] = append(loc.positions[raw], pos)
+	}
+
+	// Closing delimiter.
+	_, err = loc.dec.Token()
+
+	return err
+}
+
+func (loc *jsonLocator) readKey() (string, Position, error) {
+	pos := offsetToPosition(loc.data, loc.skipSpaces(int(loc.dec.InputOffset())))
+
+	tok, err := loc.dec.Token()
+	if err != nil {
+		return "", pos, err
+	}
+
+	key, ok := tok.(string)
+	if !ok {
+		return "", pos, fmt.Errorf("expected object key, got %v", tok)
+	}
+
+	return key, pos, nil
+}
+
+func (loc *jsonLocator) nextIsObject() bool {
+	offset := loc.skipSpaces(int(loc.dec.InputOffset()))
+
+	return offset < len(loc.data) && loc.data[offset] == '{'
+}
+
+func (loc *jsonLocator) skipValue() error {
+	var skip json.RawMessage
+
+	if err := loc.dec.Decode(&skip); err != nil && !errors.Is(err, io.EOF) {
+		return err
+	}
+
+	return nil
+}
+
+// skipSpaces returns offset of the next meaningful JSON token.
+func (loc *jsonLocator) skipSpaces(offset int) int {
+	for offset < len(loc.data) {
+		switch loc.data[offset] {
+		case ' ', '\t', '\r', '\n', ',', ':':
+			offset++
+		default:
+			return offset
+		}
+	}
+
+	return offset
+}
+
+// offsetToPosition converts a byte offset in the data to the 1-based line and column.
+func offsetToPosition(data []byte, offset int) Position {
+	if offset > len(data) {
+		offset = len(data)
+	}
+
+	line := 1 + bytes.Count(data[:offset], []byte{'\n'})
+	column := offset + 1
+
+	if i := bytes.LastIndexByte(data[:offset], '\n'); i >= 0 {
+		column = offset - i
+	}
+
+	return Position{Line: line, Column: column}
+}
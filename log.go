@@ -0,0 +1,156 @@
+package i18n
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+)
+
+// LogLevels are the levels of the bundle's log records, see the WithLogger.
+type LogLevels struct {
+	// Load is a level of the loaded files and languages records.
+	Load slog.Level
+	// Override is a level of the translations overridden by the higher layers.
+	Override slog.Level
+	// Template is a level of the template failures.
+	Template slog.Level
+	// Fallback is a level of the translations missing in the requested language.
+	Fallback slog.Level
+}
+
+// DefaultLogLevels are the levels used if the WithLogLevels is not set.
+var DefaultLogLevels = LogLevels{
+	Load:     slog.LevelDebug,
+	Override: slog.LevelDebug,
+	Template: slog.LevelError,
+	Fallback: slog.LevelDebug,
+}
+
+// WithLogger sets a logger for the bundle's diagnostics with the `lang`, `key` and `source` attributes:
+// files loaded with their languages and keys count, translations overridden by the higher layers,
+// template failures and fallbacks to another language (the `resolved_lang`, empty for the key itself).
+//
+// The logger also replaces the standard logger for the errors if the WithErrorHandler is not set.
+// Options affecting the loading, the logger must be passed to the NewBundle before the sources.
+func WithLogger(logger *slog.Logger) BundleSource {
+	return func(b *Bundle) error {
+		b.cfg.logger = logger
+
+		return nil
+	}
+}
+
+// WithLogLevels sets the levels of the log records, see the DefaultLogLevels.
+// Note that zero values are the slog.LevelInfo:
+//
+//	levels := i18n.DefaultLogLevels
+//	levels.Fallback = slog.LevelWarn
+//
+//	bundle, err := i18n.NewBundle(i18n.English, i18n.WithLogger(logger), i18n.WithLogLevels(levels), source)
+func WithLogLevels(levels LogLevels) BundleSource {
+	return func(b *Bundle) error {
+		b.cfg.logLevels = &levels
+
+		return nil
+	}
+}
+
+func (b *Bundle) getLogLevels() LogLevels {
+	if b.cfg.logLevels != nil {
+		return *b.cfg.logLevels
+	}
+
+	return DefaultLogLevels
+}
+
+// isLogEnabled returns true if the records of the level are logged, so the attributes are worth building.
+func (b *Bundle) isLogEnabled(level slog.Level) bool {
+	return b.cfg.logger != nil && b.cfg.logger.Enabled(context.Background(), level)
+}
+
+func (b *Bundle) log(level slog.Level, msg string, attrs ...slog.Attr) {
+	if !b.isLogEnabled(level) {
+		return
+	}
+
+	b.cfg.logger.LogAttrs(context.Background(), level, msg, attrs...)
+}
+
+// logLoaded logs the translations of the source loaded, the source is empty for the in-memory ones.
+func (b *Bundle) logLoaded(source string, lang Tag, keys int) {
+	b.log(
+		b.getLogLevels().Load, "i18n: translations loaded",
+		slog.String("source", source),
+		slog.String("lang", lang.String()),
+		slog.Int("keys", keys),
+		slog.String("layer", b.getLoadingLayer().name),
+	)
+}
+
+func (b *Bundle) logBundleLoaded() {
+	level := b.getLogLevels().Load
+	if !b.isLogEnabled(level) {
+		return
+	}
+
+	languages := getSortedKeys(b.translations, compareTags)
+	names := make([]string, 0, len(languages))
+	keys := 0
+
+	for _, lang := range languages {
+		names = append(names, lang.String())
+		keys += len(b.translations[lang])
+	}
+
+	b.log(level, "i18n: bundle loaded", slog.Any("languages", names), slog.Int("keys", keys))
+}
+
+func (b *Bundle) logOverride(lang Tag, key string, pos Position, l *layer, overridden *layer) {
+	b.log(
+		b.getLogLevels().Override, "i18n: translation overridden",
+		slog.String("lang", lang.String()),
+		slog.String("key", key),
+		slog.String("source", pos.String()),
+		slog.String("layer", l.name),
+		slog.String("overridden_layer", overridden.name),
+	)
+}
+
+func (b *Bundle) logTemplateError(err *TemplateError) {
+	level := b.getLogLevels().Template
+	if !b.isLogEnabled(level) {
+		return
+	}
+
+	attrs := []slog.Attr{
+		slog.String("lang", err.Language.String()),
+		slog.String("key", err.Key),
+	}
+
+	// The template is rendered from the loaded translation, so the lazy files are not loaded (and evicted) here.
+	if origin, ok := b.getLoadedOrigin(err.Language, err.Key); ok {
+		attrs = append(attrs, slog.String("source", origin.Position.String()))
+	}
+
+	b.log(level, "i18n: template failed", append(attrs, slog.Any("error", err.Err))...)
+}
+
+func (b *Bundle) logFallback(lang Tag, key string, resolvedLang Tag) {
+	b.log(
+		b.getLogLevels().Fallback, "i18n: translation fallback",
+		slog.String("lang", lang.String()),
+		slog.String("key", key),
+		slog.String("resolved_lang", resolvedLang.String()),
+	)
+}
+
+// logError logs the error passed to the handleError if there is no error handler.
+// Template errors are not logged twice, as they are logged by the prepareText.
+func (b *Bundle) logError(err error) {
+	var tplErr *TemplateError
+	if errors.As(err, &tplErr) {
+		return
+	}
+
+	b.log(slog.LevelError, "i18n: "+err.Error())
+}
@@ -0,0 +1,150 @@
+package i18n_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"testing"
+
+	"github.com/kukymbr/i18n"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBundle_WithLogger(t *testing.T) {
+	var buf bytes.Buffer
+
+	logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	bundle, err := i18n.NewBundle(
+		i18n.English,
+		i18n.WithLogger(logger),
+		i18n.FromFiles(i18n.YAML, "testdata/yaml/en.yml"),
+		i18n.FromString(i18n.YAML, `
+language: en
+translations:
+  broken: "Hello, {{ .Name.Title }}!"
+`),
+	)
+	require.NoError(t, err)
+
+	err = bundle.AddLayer("tenant", i18n.PriorityTenant, i18n.FromString(i18n.YAML, `
+language: en
+translations:
+  broken: "Hi!"
+`))
+	require.NoError(t, err)
+
+	records := getLogRecords(t, &buf)
+	require.Len(t, records, 5)
+
+	assert.Equal(t, "i18n: translations loaded", records[0]["msg"])
+	assert.Equal(t, "DEBUG", records[0]["level"])
+	assert.Equal(t, "testdata/yaml/en.yml", records[0]["source"])
+	assert.Equal(t, "en", records[0]["lang"])
+	assert.Equal(t, i18n.DefaultLayer, records[0]["layer"])
+
+	assert.Equal(t, "i18n: translations loaded", records[1]["msg"])
+	assert.Equal(t, "", records[1]["source"])
+	assert.InDelta(t, 1, records[1]["keys"], 0)
+
+	assert.Equal(t, "i18n: bundle loaded", records[2]["msg"])
+	assert.Equal(t, []any{"en"}, records[2]["languages"])
+
+	assert.Equal(t, "i18n: translations loaded", records[3]["msg"])
+	assert.Equal(t, "tenant", records[3]["layer"])
+
+	assert.Equal(t, "i18n: translation overridden", records[4]["msg"])
+	assert.Equal(t, "broken", records[4]["key"])
+	assert.Equal(t, "<input>:4:3", records[4]["source"])
+	assert.Equal(t, "tenant", records[4]["layer"])
+	assert.Equal(t, i18n.DefaultLayer, records[4]["overridden_layer"])
+
+	buf.Reset()
+	bundle.T(i18n.Spanish, "unknown")
+
+	records = getLogRecords(t, &buf)
+	require.Len(t, records, 1)
+
+	assert.Equal(t, map[string]any{
+		"level":         "DEBUG",
+		"msg":           "i18n: translation fallback",
+		"lang":          "es",
+		"key":           "unknown",
+		"resolved_lang": "",
+	}, withoutTime(records[0]))
+}
+
+func TestBundle_WithLogger_TemplateError(t *testing.T) {
+	var buf bytes.Buffer
+
+	bundle, err := i18n.NewBundle(
+		i18n.English,
+		i18n.WithLogger(slog.New(slog.NewJSONHandler(&buf, nil))),
+		i18n.FromString(i18n.YAML, `
+language: en
+translations:
+  broken: "Hello, {{ .Name.Title }}!"
+`),
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t, "Hello, {{ .Name.Title }}!", bundle.T(i18n.English, "broken", map[string]any{"Name": "John"}))
+
+	records := getLogRecords(t, &buf)
+	require.Len(t, records, 1, "template errors must not be logged twice")
+
+	assert.Equal(t, "i18n: template failed", records[0]["msg"])
+	assert.Equal(t, "ERROR", records[0]["level"])
+	assert.Equal(t, "en", records[0]["lang"])
+	assert.Equal(t, "broken", records[0]["key"])
+	assert.Equal(t, "<input>:4:3", records[0]["source"])
+	assert.Contains(t, records[0]["error"], "Title")
+}
+
+func TestBundle_WithLogLevels(t *testing.T) {
+	var buf bytes.Buffer
+
+	levels := i18n.DefaultLogLevels
+	levels.Fallback = slog.LevelWarn
+
+	bundle, err := i18n.NewBundle(
+		i18n.English,
+		i18n.WithLogger(slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelInfo}))),
+		i18n.WithLogLevels(levels),
+		i18n.FromEmbeddedFS(i18n.YAML, translationsFS, true, "testdata/example"),
+	)
+	require.NoError(t, err)
+
+	bundle.T(i18n.German, "greeting.hello")
+
+	records := getLogRecords(t, &buf)
+	require.Len(t, records, 1)
+
+	assert.Equal(t, "i18n: translation fallback", records[0]["msg"])
+	assert.Equal(t, "WARN", records[0]["level"])
+	assert.Equal(t, "de", records[0]["lang"])
+	assert.Equal(t, "en", records[0]["resolved_lang"])
+}
+
+func getLogRecords(t *testing.T, buf *bytes.Buffer) []map[string]any {
+	t.Helper()
+
+	var records []map[string]any
+
+	for line := range bytes.Lines(buf.Bytes()) {
+		var record map[string]any
+
+		require.NoError(t, json.Unmarshal(line, &record))
+
+		records = append(records, record)
+	}
+
+	return records
+}
+
+func withoutTime(record map[string]any) map[string]any {
+	delete(record, "time")
+
+	return record
+}
@@ -0,0 +1,164 @@
+package i18n
+
+import (
+	"cmp"
+	"expvar"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// MissingFunc is called when the translation of the key is missing in the requested language.
+// The resolvedLang is the language the translation was taken from: the fallback language,
+// or Und if the key itself is returned.
+type MissingFunc func(lang Tag, key string, resolvedLang Tag)
+
+// WithOnMissing registers a function called on every translation missing in the requested language,
+// including the translations of the `t` template function. Functions are called in the order of registration,
+// synchronously, so they must be fast; see the MissingCollector for the counting one.
+func WithOnMissing(fn MissingFunc) BundleSource {
+	return func(b *Bundle) error {
+		b.cfg.missingHandlers = append(slices.Clip(b.cfg.missingHandlers), fn)
+
+		return nil
+	}
+}
+
+func (b *Bundle) reportMissing(lang Tag, key string, resolvedLang Tag) {
+	b.logFallback(lang, key, resolvedLang)
+
+	for _, fn := range b.cfg.missingHandlers {
+		fn(lang, key, resolvedLang)
+	}
+}
+
+// MissingStat is a number of times the translation was missing.
+type MissingStat struct {
+	Language     Tag
+	Key          string
+	ResolvedLang Tag
+	Count        uint64
+}
+
+// MissingCollector counts missing translations per language, key and resolved language
+// (the fallback one changes when the key is added or removed), so the translation work
+// could be prioritized by the real traffic. It is safe for the concurrent use:
+//
+//	collector := i18n.NewMissingCollector()
+//	bundle, err := i18n.NewBundle(i18n.English, i18n.WithOnMissing(collector.OnMissing), source)
+//
+//	expvar.Publish("i18n_missing", collector.Expvar())
+//	http.Handle("/metrics/i18n", collector.Handler())
+//
+// Note that keys coming from the user input increase the number of the counters.
+type MissingCollector struct {
+	mu    sync.Mutex
+	stats map[missingStatKey]*MissingStat
+}
+
+type missingStatKey struct {
+	lang     Tag
+	key      string
+	resolved Tag
+}
+
+// NewMissingCollector returns a new empty MissingCollector.
+func NewMissingCollector() *MissingCollector {
+	return &MissingCollector{stats: make(map[missingStatKey]*MissingStat)}
+}
+
+// OnMissing counts the missing translation, it is a MissingFunc for the WithOnMissing.
+func (c *MissingCollector) OnMissing(lang Tag, key string, resolvedLang Tag) {
+	id := missingStatKey{lang: lang, key: key, resolved: resolvedLang}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	stat, ok := c.stats[id]
+	if !ok {
+		stat = &MissingStat{Language: lang, Key: key, ResolvedLang: resolvedLang}
+		c.stats[id] = stat
+	}
+
+	stat.Count++
+}
+
+// GetStats returns the counters sorted by the count descending, then by the language, the key
+// and the resolved language.
+func (c *MissingCollector) GetStats() []MissingStat {
+	c.mu.Lock()
+
+	stats := make([]MissingStat, 0, len(c.stats))
+	for _, stat := range c.stats {
+		stats = append(stats, *stat)
+	}
+
+	c.mu.Unlock()
+
+	slices.SortFunc(stats, func(a, b MissingStat) int {
+		return cmp.Or(
+			cmp.Compare(b.Count, a.Count),
+			compareTags(a.Language, b.Language),
+			strings.Compare(a.Key, b.Key),
+			compareTags(a.ResolvedLang, b.ResolvedLang),
+		)
+	})
+
+	return stats
+}
+
+// Reset removes all the counters.
+func (c *MissingCollector) Reset() {
+	c.mu.Lock()
+	clear(c.stats)
+	c.mu.Unlock()
+}
+
+// Expvar returns the expvar.Var with the counts by the language and the key: {"es": {"greeting.bye": 3}}.
+func (c *MissingCollector) Expvar() expvar.Var {
+	return expvar.Func(func() any {
+		result := make(map[string]map[string]uint64)
+
+		for _, stat := range c.GetStats() {
+			lang := stat.Language.String()
+			if result[lang] == nil {
+				result[lang] = make(map[string]uint64)
+			}
+
+			result[lang][stat.Key] += stat.Count
+		}
+
+		return result
+	})
+}
+
+// Handler returns the http.Handler writing the counters in the Prometheus text format:
+//
+//	i18n_missing_translations_total{language="es",key="greeting.bye",resolved="en"} 3
+func (c *MissingCollector) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+
+		var buf strings.Builder
+
+		buf.WriteString("# HELP i18n_missing_translations_total Number of translations missing in the requested language.\n")
+		buf.WriteString("# TYPE i18n_missing_translations_total counter\n")
+
+		for _, stat := range c.GetStats() {
+			fmt.Fprintf(
+				&buf, "i18n_missing_translations_total{language=\"%s\",key=\"%s\",resolved=\"%s\"} %d\n",
+				escapeLabel(stat.Language.String()), escapeLabel(stat.Key), escapeLabel(stat.ResolvedLang.String()), stat.Count,
+			)
+		}
+
+		_, _ = w.Write([]byte(buf.String()))
+	})
+}
+
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func escapeLabel(s string) string {
+	return labelEscaper.Replace(s)
+}
@@ -0,0 +1,121 @@
+package i18n_test
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/kukymbr/i18n"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type missingCall struct {
+	Lang     i18n.Tag
+	Key      string
+	Resolved i18n.Tag
+}
+
+func TestBundle_OnMissing(t *testing.T) {
+	var calls []missingCall
+
+	bundle, err := i18n.NewBundle(
+		i18n.English,
+		i18n.WithOnMissing(func(lang i18n.Tag, key string, resolvedLang i18n.Tag) {
+			calls = append(calls, missingCall{lang, key, resolvedLang})
+		}),
+		i18n.WithPseudoLocales(0),
+		i18n.FromEmbeddedFS(i18n.YAML, translationsFS, true, "testdata/example"),
+		i18n.FromString(i18n.YAML, `
+language: en
+translations:
+  bye: "Bye!"
+  nested: "{{ t \"unknown.nested\" }}"
+`),
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t, "¡Hola!", bundle.T(i18n.Spanish, "greeting.hello"))
+	assert.Equal(t, "Bye!", bundle.T(i18n.Spanish, "bye"))
+	assert.Equal(t, "unknown", bundle.T(i18n.English, "unknown"))
+	assert.Equal(t, "unknown", bundle.T(i18n.Spanish, "unknown"))
+	assert.Equal(t, "unknown.nested", bundle.T(i18n.English, "nested"))
+	assert.Equal(t, "unknown", bundle.T(i18n.PseudoAccented, "unknown"))
+
+	assert.Equal(t, []missingCall{
+		{i18n.Spanish, "bye", i18n.English},
+		{i18n.English, "unknown", i18n.Und},
+		{i18n.Spanish, "unknown", i18n.Und},
+		{i18n.English, "unknown.nested", i18n.Und},
+		{i18n.PseudoAccented, "unknown", i18n.Und},
+	}, calls)
+}
+
+func TestMissingCollector(t *testing.T) {
+	collector := i18n.NewMissingCollector()
+
+	bundle, err := i18n.NewBundle(
+		i18n.English,
+		i18n.WithOnMissing(collector.OnMissing),
+		i18n.FromEmbeddedFS(i18n.YAML, translationsFS, true, "testdata/example"),
+		i18n.FromString(i18n.YAML, "language: en\ntranslations:\n  bye: Bye!\n"),
+	)
+	require.NoError(t, err)
+
+	var wg sync.WaitGroup
+
+	for range 10 {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			bundle.T(i18n.Spanish, "bye")
+			bundle.T(i18n.German, "greeting.hello")
+		}()
+	}
+
+	wg.Wait()
+
+	bundle.T(i18n.Spanish, `say "hi"`)
+	// The key removed from the fallback language is counted separately, keeping the series continuous.
+	collector.OnMissing(i18n.Spanish, "bye", i18n.Und)
+
+	assert.Equal(t, []i18n.MissingStat{
+		{Language: i18n.German, Key: "greeting.hello", ResolvedLang: i18n.English, Count: 10},
+		{Language: i18n.Spanish, Key: "bye", ResolvedLang: i18n.English, Count: 10},
+		{Language: i18n.Spanish, Key: "bye", ResolvedLang: i18n.Und, Count: 1},
+		{Language: i18n.Spanish, Key: `say "hi"`, ResolvedLang: i18n.Und, Count: 1},
+	}, collector.GetStats())
+
+	t.Run("expvar", func(t *testing.T) {
+		var vars map[string]map[string]uint64
+
+		require.NoError(t, json.Unmarshal([]byte(collector.Expvar().String()), &vars))
+		assert.Equal(t, map[string]map[string]uint64{
+			"de": {"greeting.hello": 10},
+			"es": {"bye": 11, `say "hi"`: 1},
+		}, vars)
+	})
+
+	t.Run("prometheus", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		collector.Handler().ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+
+		assert.Equal(t, "text/plain; version=0.0.4; charset=utf-8", rec.Header().Get("Content-Type"))
+		assert.Equal(t, `# HELP i18n_missing_translations_total Number of translations missing in the requested language.
+# TYPE i18n_missing_translations_total counter
+i18n_missing_translations_total{language="de",key="greeting.hello",resolved="en"} 10
+i18n_missing_translations_total{language="es",key="bye",resolved="en"} 10
+i18n_missing_translations_total{language="es",key="bye",resolved=""} 1
+i18n_missing_translations_total{language="es",key="say \"hi\"",resolved=""} 1
+`, rec.Body.String())
+	})
+
+	t.Run("reset", func(t *testing.T) {
+		collector.Reset()
+
+		assert.Empty(t, collector.GetStats())
+	})
+}
@@ -0,0 +1,110 @@
+package i18n
+
+import (
+	"errors"
+	"log"
+	"log/slog"
+)
+
+// WithDuplicatePolicy sets the policy for the keys defined more than once in the same layer.
+// Note that the same key repeated in a single JSON object is resolved by the unmarshaler,
+// which keeps the last value regardless of the policy (the duplicate is still reported).
+func WithDuplicatePolicy(policy DuplicatePolicy) BundleSource {
+	return func(b *Bundle) error {
+		b.cfg.duplicatePolicy = policy
+
+		return nil
+	}
+}
+
+// WithErrorHandler sets a handler receiving non-fatal errors of the bundle.
+// By default, errors are written to the standard logger,
+// template errors of the Translate only once per language and key.
+func WithErrorHandler(fn func(err error)) BundleSource {
+	return func(b *Bundle) error {
+		b.cfg.errorHandler = fn
+
+		return nil
+	}
+}
+
+// WithStrictTemplates makes the translation templates fail on the data missing in the maps (missingkey=error),
+// instead of rendering the "<no value>" or an empty string.
+func WithStrictTemplates() BundleSource {
+	return func(b *Bundle) error {
+		b.cfg.strictTemplates = true
+
+		return nil
+	}
+}
+
+// WithStructCollections makes the TranslateStruct translate elements of the string slices and values of the maps.
+// Values of the strings are used as translation keys.
+func WithStructCollections() BundleSource {
+	return func(b *Bundle) error {
+		b.cfg.structCollections = true
+
+		return nil
+	}
+}
+
+type bundleConfig struct {
+	duplicatePolicy   DuplicatePolicy
+	errorHandler      func(err error)
+	engine            TemplateEngine
+	funcs             FuncMap
+	langFuncs         []func(lang Tag) FuncMap
+	strictTemplates   bool
+	validate          bool
+	structCollections bool
+	pseudoLocales     bool
+	pseudoExpansion   float64
+	missingHandlers   []MissingFunc
+	logger            *slog.Logger
+	logLevels         *LogLevels
+}
+
+// handleErrors passes the errors to the handleError, the bundle must not be locked.
+func (b *Bundle) handleErrors(errs []error) {
+	for _, err := range errs {
+		b.handleError(err)
+	}
+}
+
+// takeWarnings returns and clears the errors found under the lock.
+func (b *Bundle) takeWarnings() []error {
+	warnings := b.warnings
+	b.warnings = nil
+
+	return warnings
+}
+
+func (b *Bundle) handleError(err error) {
+	if b.cfg.errorHandler != nil {
+		b.cfg.errorHandler(err)
+
+		return
+	}
+
+	if b.cfg.logger != nil {
+		b.logError(err)
+
+		return
+	}
+
+	// The broken translation fails on every call, so it is logged once not to flood the output.
+	var tplErr *TemplateError
+	if errors.As(err, &tplErr) {
+		id := templateErrorKey{lang: tplErr.Language, key: tplErr.Key}
+		if _, logged := b.loggedTemplateErrors.LoadOrStore(id, struct{}{}); logged {
+			return
+		}
+	}
+
+	log.Printf("i18n: %v", err)
+}
+
+type templateErrorKey struct {
+	lang Tag
+	key  string
+}
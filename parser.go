@@ -1,7 +1,11 @@
 package i18n
 
 import (
+	"cmp"
+	"errors"
 	"fmt"
+	"slices"
+	"strings"
 )
 
 type unmarshalDTO struct {
@@ -9,61 +13,144 @@ type unmarshalDTO struct {
 	Translations map[string]any `yaml:"translations" json:"translations" db:"translations" bson:"translations" xml:"translations"`
 }
 
-func unmarshal(dataType DataType, data []byte) (Tag, Translations, error) {
+// translationsFile is a parsed translations source.
+type translationsFile struct {
+	path        string
+	lang        Tag
+	definitions []definition
+	// shadowed contains positions of the repeated keys, overwritten by the unmarshaler itself.
+	shadowed map[string][]Position
+}
+
+// definition is a single translation definition from the source.
+type definition struct {
+	key  string
+	raw  string
+	text string
+	pos  Position
+}
+
+func unmarshal(dataType DataType, path string, data []byte) (*translationsFile, error) {
 	dto := unmarshalDTO{}
-	translations := Translations{}
 
 	fn, err := getUnmarshaler(dataType)
 	if err != nil {
-		return Und, nil, err
+		return nil, &LoadError{Path: path, Err: err}
 	}
 
 	if err := fn(data, &dto); err != nil {
-		return Und, nil, fmt.Errorf("failed to unmarshal translations data: %w", err)
+		return nil, newLoadError(path, data, fmt.Errorf("failed to unmarshal translations data: %w", err))
 	}
 
-	if err := parseTranslations("", dto.Translations, translations); err != nil {
-		return Und, nil, fmt.Errorf("failed to parse translations: %w", err)
+	positions := locateKeys(dataType, data)
+
+	definitions, err := parseTranslations(nil, dto.Translations, nil)
+	if err != nil {
+		var loadErr *LoadError
+		if errors.As(err, &loadErr) {
+			loadErr.Path = path
+
+			if found := positions[loadErr.raw]; len(found) > 0 {
+				loadErr.setPosition(found[0])
+			}
+		}
+
+		return nil, err
 	}
 
+	file := &translationsFile{path: path, lang: Und}
+	file.setDefinitions(definitions, positions)
+
 	if dto.Language == "" {
-		return Und, translations, nil
+		return file, nil
 	}
 
 	lang, err := Parse(dto.Language)
 	if err != nil {
-		return Und, nil, fmt.Errorf("failed to parse language '%s': %w", dto.Language, err)
+		return nil, &LoadError{
+			Path: path,
+			Key:  "language",
+			Err:  fmt.Errorf("failed to parse language '%s': %w", dto.Language, err),
+		}
 	}
 
-	return lang, translations, nil
+	file.lang = lang
+
+	return file, nil
 }
 
-func parseTranslations(parentKey string, inp map[string]any, target Translations) error {
+func parseTranslations(parent []string, inp map[string]any, target []definition) ([]definition, error) {
 	for k, v := range inp {
-		key := k
-
-		if parentKey != "" {
-			key = parentKey + "." + k
-		}
+		segments := append(parent[:len(parent):len(parent)], k)
+		key := strings.Join(segments, ".")
 
 		if s, ok := v.(string); ok {
-			target[key] = s
+			target = append(target, definition{key: key, raw: joinRawPath(segments), text: s})
 
 			continue
 		}
 
 		if m, ok := v.(map[string]any); ok {
-			if err := parseTranslations(key, m, target); err != nil {
-				return err
+			var err error
+
+			target, err = parseTranslations(segments, m, target)
+			if err != nil {
+				return nil, err
 			}
 
 			continue
 		}
 
-		return fmt.Errorf("key %s: expected string or map, got %T", key, v)
+		return nil, &LoadError{
+			Key: key,
+			Err: fmt.Errorf("expected string or map, got %T", v),
+			raw: joinRawPath(segments),
+		}
 	}
 
-	return nil
+	return target, nil
+}
+
+// setDefinitions assigns positions to the definitions and sorts them in the document order,
+// so the conflicting definitions (like `a.b` and `a: {b}`) are applied predictably.
+func (f *translationsFile) setDefinitions(definitions []definition, positions map[string][]Position) {
+	for i, def := range definitions {
+		found := positions[def.raw]
+		if len(found) == 0 {
+			definitions[i].pos = Position{Path: f.path}
+
+			continue
+		}
+
+		// Unmarshalers keep the last value of the repeated key.
+		definitions[i].pos = found[len(found)-1]
+		definitions[i].pos.Path = f.path
+
+		if len(found) > 1 {
+			if f.shadowed == nil {
+				f.shadowed = make(map[string][]Position)
+			}
+
+			for _, pos := range found[:len(found)-1] {
+				pos.Path = f.path
+				f.shadowed[def.key] = append(f.shadowed[def.key], pos)
+			}
+		}
+	}
+
+	slices.SortFunc(definitions, func(a, b definition) int {
+		if c := cmp.Compare(a.pos.Line, b.pos.Line); c != 0 {
+			return c
+		}
+
+		if c := cmp.Compare(a.pos.Column, b.pos.Column); c != 0 {
+			return c
+		}
+
+		return cmp.Compare(a.raw, b.raw)
+	})
+
+	f.definitions = definitions
 }
 
 func getUnmarshaler(dataType DataType) (UnmarshalerFunc, error) {
@@ -0,0 +1,197 @@
+package i18n
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"text/template/parse"
+)
+
+// PlaceholdersReport is a result of the Bundle.CheckPlaceholders.
+type PlaceholdersReport struct {
+	Problems []PlaceholderProblem
+}
+
+// PlaceholderProblem describes a translation, which placeholders differ from the fallback language's ones.
+// Template fields are listed as `.Name`, ICU-style arguments as `{name}`.
+type PlaceholderProblem struct {
+	Language Tag
+	Key      string
+	// Missing are placeholders of the fallback language's translation absent in this one.
+	Missing []string
+	// Extra are placeholders of this translation absent in the fallback language's one.
+	Extra []string
+}
+
+func (p PlaceholderProblem) String() string {
+	msg := "key " + p.Key + " (language '" + p.Language.String() + "'):"
+
+	if len(p.Missing) > 0 {
+		msg += " missing " + strings.Join(p.Missing, ", ")
+	}
+
+	if len(p.Extra) > 0 {
+		if len(p.Missing) > 0 {
+			msg += ";"
+		}
+
+		msg += " extra " + strings.Join(p.Extra, ", ")
+	}
+
+	return msg
+}
+
+// CheckPlaceholders compares placeholders used by the translations of each key in the fallback language
+// with the ones of the other languages. Problems are sorted by the language and key.
+//
+// Keys absent in the fallback language or in the checked one are skipped,
+// as well as the translations failing to parse (see the Bundle.Validate).
+// Note that all the registered lazy files are loaded by the check.
+func (b *Bundle) CheckPlaceholders() PlaceholdersReport {
+	b.ensureAllLanguages()
+
+	var report PlaceholdersReport
+
+	base, _ := b.getLanguageTranslations(b.fallbackLanguage)
+	basePlaceholders := make(map[string][]string, len(base))
+
+	for key, text := range base {
+		if placeholders, err := extractPlaceholders(text); err == nil {
+			basePlaceholders[key] = placeholders
+		}
+	}
+
+	for _, lang := range b.getLanguages() {
+		if lang == b.fallbackLanguage {
+			continue
+		}
+
+		translations, _ := b.getLanguageTranslations(lang)
+
+		for _, key := range getSortedKeys(translations, strings.Compare) {
+			expected, ok := basePlaceholders[key]
+			if !ok {
+				continue
+			}
+
+			placeholders, err := extractPlaceholders(translations[key])
+			if err != nil {
+				continue
+			}
+
+			problem := PlaceholderProblem{
+				Language: lang,
+				Key:      key,
+				Missing:  subtract(expected, placeholders),
+				Extra:    subtract(placeholders, expected),
+			}
+
+			if len(problem.Missing) > 0 || len(problem.Extra) > 0 {
+				report.Problems = append(report.Problems, problem)
+			}
+		}
+	}
+
+	return report
+}
+
+// rxICUArgument matches ICU message format arguments: `{name}` or `{count, plural, ...}`.
+var rxICUArgument = regexp.MustCompile(`\{\s*([\p{L}_][\p{L}\p{N}_]*)\s*[,}]`)
+
+// extractPlaceholders returns sorted unique placeholders of the text.
+func extractPlaceholders(text string) ([]string, error) {
+	found := make(map[string]struct{})
+
+	if !isTemplate(text) {
+		collectICUArguments(text, found)
+
+		return getSortedKeys(found, strings.Compare), nil
+	}
+
+	tree := parse.New("placeholders")
+	tree.Mode = parse.SkipFuncCheck
+
+	if _, err := tree.Parse(text, "", "", make(map[string]*parse.Tree)); err != nil {
+		return nil, fmt.Errorf("failed to parse template: %w", err)
+	}
+
+	walkPlaceholders(tree.Root, found, false)
+
+	return getSortedKeys(found, strings.Compare), nil
+}
+
+func collectICUArguments(text string, found map[string]struct{}) {
+	for _, match := range rxICUArgument.FindAllStringSubmatch(text, -1) {
+		found["{"+match[1]+"}"] = struct{}{}
+	}
+}
+
+// walkPlaceholders collects placeholders of the node; in nested with and range blocks,
+// only the $-rooted fields are collected, as the rest are relative to the new dot.
+//
+//nolint:cyclop // plain switch over the node types
+func walkPlaceholders(node parse.Node, found map[string]struct{}, nested bool) {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return
+		}
+
+		for _, child := range n.Nodes {
+			walkPlaceholders(child, found, nested)
+		}
+	case *parse.TextNode:
+		collectICUArguments(string(n.Text), found)
+	case *parse.ActionNode:
+		walkPlaceholders(n.Pipe, found, nested)
+	case *parse.PipeNode:
+		if n == nil {
+			return
+		}
+
+		for _, cmd := range n.Cmds {
+			walkPlaceholders(cmd, found, nested)
+		}
+	case *parse.CommandNode:
+		for _, arg := range n.Args {
+			walkPlaceholders(arg, found, nested)
+		}
+	case *parse.FieldNode:
+		if !nested {
+			found["."+strings.Join(n.Ident, ".")] = struct{}{}
+		}
+	case *parse.VariableNode:
+		if len(n.Ident) > 1 && n.Ident[0] == "$" {
+			found["."+strings.Join(n.Ident[1:], ".")] = struct{}{}
+		}
+	case *parse.IfNode:
+		walkBranchPlaceholders(&n.BranchNode, found, nested, nested)
+	case *parse.RangeNode:
+		walkBranchPlaceholders(&n.BranchNode, found, nested, true)
+	case *parse.WithNode:
+		walkBranchPlaceholders(&n.BranchNode, found, nested, true)
+	case *parse.TemplateNode:
+		walkPlaceholders(n.Pipe, found, nested)
+	}
+}
+
+// walkBranchPlaceholders collects placeholders of the branch; listNested is true if the branch changes the dot.
+func walkBranchPlaceholders(n *parse.BranchNode, found map[string]struct{}, nested bool, listNested bool) {
+	walkPlaceholders(n.Pipe, found, nested)
+	walkPlaceholders(n.List, found, listNested)
+	walkPlaceholders(n.ElseList, found, nested)
+}
+
+// subtract returns values of a absent in b, keeping their order.
+func subtract(a []string, b []string) []string {
+	var result []string
+
+	for _, v := range a {
+		if !slices.Contains(b, v) {
+			result = append(result, v)
+		}
+	}
+
+	return result
+}
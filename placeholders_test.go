@@ -0,0 +1,58 @@
+package i18n_test
+
+import (
+	"testing"
+
+	"github.com/kukymbr/i18n"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBundle_CheckPlaceholders(t *testing.T) {
+	bundle, err := i18n.NewBundle(
+		i18n.English,
+		i18n.FromString(i18n.YAML, `
+language: en
+translations:
+  hello: "Hello, {{ .Name }}!"
+  cart: "{{ .Count }} {{ plural .Count \"one=item\" \"other=items\" }} in {{ .Cart.Title }}"
+  list: "{{ range .Items }}{{ .Title }}{{ end }}"
+  scoped: "{{ range .Items }}{{ .Name }}, {{ end }}{{ with .User }}{{ .Name }} {{ $.Total }}{{ end }}"
+  icu: "{count, plural, one {# file} other {# files}} of {owner}"
+  plain: "Hello"
+`),
+		i18n.FromString(i18n.YAML, `
+language: es
+translations:
+  hello: "¡Hola, {{ .Nombre }}!"
+  cart: "{{ .Count }} en {{ .Cart.Title }}"
+  list: "{{ range .Items }}{{ .Title }}{{ end }}"
+  scoped: "{{ .Total }} {{ .User }}: {{ range $item := .Items }}{{ $item.Name }}{{ end }}"
+  icu: "{count, plural, one {# archivo} other {# archivos}} de {owner}"
+  plain: "Hola, {{ .Name }}"
+  spanish_only: "{{ .Unknown }}"
+`),
+		i18n.FromString(i18n.YAML, `
+language: de
+translations:
+  hello: "Hallo, {{ .Name }}!"
+  icu: "{count, plural, one {# Datei} other {# Dateien}}"
+  list: "{{ range .Items "
+`),
+	)
+	require.NoError(t, err)
+
+	report := bundle.CheckPlaceholders()
+
+	assert.Equal(t, []i18n.PlaceholderProblem{
+		{Language: i18n.German, Key: "icu", Missing: []string{"{owner}"}},
+		{Language: i18n.Spanish, Key: "hello", Missing: []string{".Name"}, Extra: []string{".Nombre"}},
+		{Language: i18n.Spanish, Key: "plain", Extra: []string{".Name"}},
+	}, report.Problems)
+
+	assert.Equal(
+		t,
+		"key hello (language 'es'): missing .Name; extra .Nombre",
+		report.Problems[1].String(),
+	)
+}
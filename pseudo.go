@@ -0,0 +1,150 @@
+package i18n
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Pseudo-locales synthesized from the fallback language, see the WithPseudoLocales.
+var (
+	// PseudoAccented is the en-XA pseudo-locale: `Hello, {{ .Name }}!` becomes `[Ĥéļļö, {{ .Name }}! on]`.
+	PseudoAccented = MustParse("en-XA")
+	// PseudoBidi is the ar-XB pseudo-locale: words are wrapped with the right-to-left override marks.
+	PseudoBidi = MustParse("ar-XB")
+)
+
+// DefaultPseudoExpansion is a ratio the pseudo-localized texts are lengthened by,
+// simulating the languages with the longer words, like German.
+const DefaultPseudoExpansion = 0.3
+
+// WithPseudoLocales enables the PseudoAccented and PseudoBidi languages, translated on the fly
+// from the fallback language. Texts are wrapped in brackets and lengthened by the expansion ratio
+// (use the DefaultPseudoExpansion), template actions, ICU arguments, HTML tags and printf verbs are kept as is.
+// QA could use them to find the hard-coded strings, truncated texts and the layout broken by the RTL.
+// Missing keys are returned as is, so they are not mistaken for the localized texts.
+//
+// Pseudo-locales are not listed in the exports. Note that the template functions
+// format numbers and select plural forms by the rules of their base languages.
+func WithPseudoLocales(expansion float64) BundleSource {
+	return func(b *Bundle) error {
+		if expansion < 0 || math.IsNaN(expansion) {
+			return fmt.Errorf("invalid pseudo-locales expansion %v, expected a non-negative ratio", expansion)
+		}
+
+		b.cfg.pseudoLocales = true
+		b.cfg.pseudoExpansion = expansion
+
+		return nil
+	}
+}
+
+func isPseudoLocale(lang Tag) bool {
+	return lang == PseudoAccented || lang == PseudoBidi
+}
+
+// rxPseudoPreserved matches the parts of the text not changed by the pseudo-localization:
+// template actions, ICU arguments with one level of nesting, HTML tags and entities, printf verbs.
+var rxPseudoPreserved = regexp.MustCompile(
+	`(?s)\{\{.*?\}\}` +
+		`|\{[^{}]*(?:\{[^{}]*\}[^{}]*)*\}` +
+		`|</?[a-zA-Z][^<>]*>` +
+		`|&[a-zA-Z0-9#]+;` +
+		`|%(?:\[\d+\])?[-+# 0]*\d*(?:\.\d+)?[a-zA-Z%]`,
+)
+
+const (
+	rightToLeftMark     = "\u200f"
+	rightToLeftOverride = "\u202e"
+	popDirectional      = "\u202c"
+)
+
+var pseudoPadding = []rune(" one two three four five six seven eight nine ten")
+
+func pseudoLocalize(lang Tag, text string, expansion float64) string {
+	var (
+		buf    strings.Builder
+		length int
+		last   int
+	)
+
+	transform := func(s string) {
+		length += utf8.RuneCountInString(s)
+
+		if lang == PseudoBidi {
+			buf.WriteString(overrideWords(s))
+		} else {
+			buf.WriteString(strings.Map(accentRune, s))
+		}
+	}
+
+	buf.WriteString("[")
+
+	for _, loc := range rxPseudoPreserved.FindAllStringIndex(text, -1) {
+		transform(text[last:loc[0]])
+		buf.WriteString(text[loc[0]:loc[1]])
+		last = loc[1]
+	}
+
+	transform(text[last:])
+
+	for i := range int(math.Ceil(float64(length) * expansion)) {
+		buf.WriteRune(pseudoPadding[i%len(pseudoPadding)])
+	}
+
+	buf.WriteString("]")
+
+	if lang == PseudoBidi {
+		return rightToLeftMark + buf.String() + rightToLeftMark
+	}
+
+	return buf.String()
+}
+
+// overrideWords wraps the words with the right-to-left override, so they are displayed mirrored.
+func overrideWords(s string) string {
+	var (
+		buf    strings.Builder
+		inWord bool
+	)
+
+	for _, r := range s {
+		isWordRune := unicode.IsLetter(r) || unicode.IsDigit(r)
+
+		switch {
+		case isWordRune && !inWord:
+			buf.WriteString(rightToLeftOverride)
+		case !isWordRune && inWord:
+			buf.WriteString(popDirectional)
+		}
+
+		inWord = isWordRune
+		buf.WriteRune(r)
+	}
+
+	if inWord {
+		buf.WriteString(popDirectional)
+	}
+
+	return buf.String()
+}
+
+var accentedRunes = map[rune]rune{
+	'a': 'å', 'b': 'ƀ', 'c': 'ç', 'd': 'ð', 'e': 'é', 'f': 'ƒ', 'g': 'ĝ', 'h': 'ĥ', 'i': 'î', 'j': 'ĵ',
+	'k': 'ķ', 'l': 'ļ', 'm': 'ɱ', 'n': 'ñ', 'o': 'ö', 'p': 'þ', 'q': 'ǫ', 'r': 'ŕ', 's': 'š', 't': 'ţ',
+	'u': 'û', 'v': 'ṽ', 'w': 'ŵ', 'x': 'ẋ', 'y': 'ý', 'z': 'ž',
+	'A': 'Å', 'B': 'Ɓ', 'C': 'Ç', 'D': 'Ð', 'E': 'É', 'F': 'Ƒ', 'G': 'Ĝ', 'H': 'Ĥ', 'I': 'Î', 'J': 'Ĵ',
+	'K': 'Ķ', 'L': 'Ļ', 'M': 'Ṁ', 'N': 'Ñ', 'O': 'Ö', 'P': 'Þ', 'Q': 'Ǫ', 'R': 'Ŕ', 'S': 'Š', 'T': 'Ţ',
+	'U': 'Û', 'V': 'Ṽ', 'W': 'Ŵ', 'X': 'Ẋ', 'Y': 'Ý', 'Z': 'Ž',
+}
+
+func accentRune(r rune) rune {
+	if accented, ok := accentedRunes[r]; ok {
+		return accented
+	}
+
+	return r
+}
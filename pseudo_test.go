@@ -0,0 +1,74 @@
+package i18n_test
+
+import (
+	"testing"
+
+	"github.com/kukymbr/i18n"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBundle_PseudoLocales(t *testing.T) {
+	source := i18n.FromString(i18n.YAML, `
+language: en
+translations:
+  hello_name: "Hello, {{ .Name }}!"
+  cart: "{{ .Count }} {{ plural .Count \"one=item\" \"other=items\" }}"
+  markup: "<b>Save</b> &amp; exit"
+  icu: "{count, plural, one {# file} other {# files}} of {owner}"
+  printf: "Hi, %s! You have %[2]d messages."
+  nested_missing: "Go to {{ t \"missing.key\" }}"
+`)
+
+	bundle, err := i18n.NewBundle(i18n.English, i18n.WithPseudoLocales(0), source)
+	require.NoError(t, err)
+
+	tests := []struct {
+		Lang     i18n.Tag
+		Key      string
+		Data     any
+		Expected string
+	}{
+		{i18n.PseudoAccented, "hello_name", map[string]any{"Name": "Mateo"}, "[Ĥéļļö, Mateo!]"},
+		{i18n.PseudoAccented, "cart", map[string]any{"Count": 2}, "[2 items]"},
+		{i18n.PseudoAccented, "markup", nil, "[<b>Šåṽé</b> &amp; éẋîţ]"},
+		{
+			i18n.PseudoAccented, "icu", nil,
+			"[{count, plural, one {# file} other {# files}} öƒ {owner}]",
+		},
+		{i18n.PseudoAccented, "printf", nil, "[Ĥî, %s! Ýöû ĥåṽé %[2]d ɱéššåĝéš.]"},
+		{i18n.PseudoAccented, "missing.key", nil, "missing.key"},
+		{i18n.PseudoAccented, "nested_missing", nil, "[Ĝö ţö missing.key]"},
+		{
+			i18n.PseudoBidi, "hello_name", map[string]any{"Name": "Mateo"},
+			"\u200f[\u202eHello\u202c, Mateo!]\u200f",
+		},
+		{i18n.English, "hello_name", map[string]any{"Name": "Mateo"}, "Hello, Mateo!"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Lang.String()+"/"+test.Key, func(t *testing.T) {
+			assert.Equal(t, test.Expected, bundle.T(test.Lang, test.Key, test.Data, i18n.TextEngine))
+		})
+	}
+
+	t.Run("expansion", func(t *testing.T) {
+		expanded, err := i18n.NewBundle(i18n.English, i18n.WithPseudoLocales(i18n.DefaultPseudoExpansion), source)
+		require.NoError(t, err)
+
+		assert.Equal(t, "[Ĥéļļö, Mateo! on]", expanded.T(i18n.PseudoAccented, "hello_name", map[string]any{"Name": "Mateo"}))
+		assert.Equal(t, "[<b>Šåṽé</b> &amp; éẋîţ on]", expanded.T(i18n.PseudoAccented, "markup"))
+	})
+
+	t.Run("when disabled", func(t *testing.T) {
+		plain, err := i18n.NewBundle(i18n.English, source)
+		require.NoError(t, err)
+
+		assert.Equal(t, "Hello, Mateo!", plain.T(i18n.PseudoAccented, "hello_name", map[string]any{"Name": "Mateo"}))
+	})
+
+	t.Run("invalid expansion", func(t *testing.T) {
+		_, err := i18n.NewBundle(i18n.English, i18n.WithPseudoLocales(-1), source)
+		require.Error(t, err)
+	})
+}
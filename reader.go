@@ -1,7 +1,7 @@
 package i18n
 
 import (
-	"embed"
+	"errors"
 	"fmt"
 	"io/fs"
 	"os"
@@ -9,10 +9,71 @@ import (
 	"strings"
 )
 
-func readFromDirectory(path string, dataType DataType, recursive bool, each func(Tag, Translations)) error {
+// fileRef is a reference to the translations file, read from the OS or from the given fs.FS.
+type fileRef struct {
+	fsys     fs.FS
+	path     string
+	dataType DataType
+}
+
+func (r fileRef) read() (*translationsFile, error) {
+	var (
+		data []byte
+		err  error
+	)
+
+	if r.fsys != nil {
+		data, err = fs.ReadFile(r.fsys, r.path)
+	} else {
+		data, err = os.ReadFile(r.path)
+	}
+
+	if err != nil {
+		return nil, &LoadError{Path: r.path, Err: fmt.Errorf("failed to read i18n file: %w", err)}
+	}
+
+	return readFromBytes(r.path, data, r.dataType)
+}
+
+// inferLanguage tries to get language of the file from its name (`<name>.<lang>.<ext>` or `<lang>.<ext>`)
+// or from the name of its directory. As the words like `app`, `api` or `new` are valid ISO 639-3 codes,
+// only the languages with the two-letter ISO 639-1 codes and the languages of the known one are accepted.
+func (r fileRef) inferLanguage(known Tag) (Tag, bool) {
+	name := strings.TrimSuffix(filepath.Base(r.path), filepath.Ext(r.path))
+	candidates := []string{
+		name[strings.LastIndex(name, ".")+1:],
+		filepath.Base(filepath.Dir(r.path)),
+	}
+
+	for _, candidate := range candidates {
+		if lang, err := Parse(candidate); err == nil && lang.IsValid() && isInferableLanguage(lang, known) {
+			return lang, true
+		}
+	}
+
+	return Und, false
+}
+
+func isInferableLanguage(lang Tag, known Tag) bool {
+	base, _ := lang.Base()
+	knownBase, _ := known.Base()
+
+	return len(base.String()) == 2 || base == knownBase
+}
+
+// readFromDirectory lists all the accepted files from the directory.
+// Unreadable entries do not stop the listing, their errors are joined into the result.
+func readFromDirectory(path string, dataType DataType, recursive bool) ([]fileRef, error) {
+	var (
+		refs []fileRef
+		errs []error
+	)
+
 	err := filepath.WalkDir(path, func(entryPath string, entry fs.DirEntry, err error) error {
 		if err != nil {
-			return err
+			errs = append(errs, &LoadError{Path: entryPath, Err: err})
+
+			return nil
 		}
 
 		if path == entryPath {
@@ -35,38 +96,31 @@ func readFromDirectory(path string, dataType DataType, recursive bool, each func
 			return nil
 		}
 
-		if !acceptFile(dataType, entry.Name()) {
-			return nil
+		if acceptFile(dataType, entry.Name()) {
+			refs = append(refs, fileRef{path: entryPath, dataType: dataType})
 		}
 
-		lang, translations, err := readFromFile(entryPath, dataType)
-		if err != nil {
-			return err
-		}
-
-		each(lang, translations)
-
 		return nil
 	})
 	if err != nil {
-		return err
+		errs = append(errs, err)
 	}
 
-	return nil
+	return refs, errors.Join(errs...)
 }
 
-func readFromEmbeddedDirectory(
-	fs embed.FS,
-	path string,
-	dataType DataType,
-	recursive bool,
-	each func(Tag, Translations),
-) error {
-	entries, err := fs.ReadDir(path)
+// readFromEmbeddedDirectory lists all the accepted files from the directory of the fs.FS.
+func readFromEmbeddedDirectory(fsys fs.FS, path string, dataType DataType, recursive bool) ([]fileRef, error) {
+	entries, err := fs.ReadDir(fsys, path)
 	if err != nil {
-		return fmt.Errorf("failed to read embedded directory: %w", err)
+		return nil, &LoadError{Path: path, Err: fmt.Errorf("failed to read embedded directory: %w", err)}
 	}
 
+	var (
+		refs []fileRef
+		errs []error
+	)
+
 	for _, entry := range entries {
 		entryPath := filepath.Join(path, entry.Name())
 
@@ -79,58 +133,23 @@ func readFromEmbeddedDirectory(
 				continue
 			}
 
-			err := readFromEmbeddedDirectory(fs, entryPath, dataType, recursive, each)
-			if err != nil {
-				return fmt.Errorf("%s: %w", entryPath, err)
-			}
+			found, err := readFromEmbeddedDirectory(fsys, entryPath, dataType, recursive)
+			refs = append(refs, found...)
+			errs = append(errs, err)
 
 			continue
 		}
 
-		if !acceptFile(dataType, entry.Name()) {
-			return nil
-		}
-
-		if err := readFromEmbeddedFile(fs, entryPath, dataType, each); err != nil {
-			return err
+		if acceptFile(dataType, entry.Name()) {
+			refs = append(refs, fileRef{fsys: fsys, path: entryPath, dataType: dataType})
 		}
 	}
 
-	return nil
-}
-
-func readFromEmbeddedFile(
-	fs embed.FS,
-	path string,
-	dataType DataType,
-	each func(Tag, Translations),
-) error {
-	data, err := fs.ReadFile(path)
-	if err != nil {
-		return fmt.Errorf("failed to read embedded file %s: %w", path, err)
-	}
-
-	lang, translations, err := readFromBytes(data, dataType)
-	if err != nil {
-		return fmt.Errorf("%s: %w", path, err)
-	}
-
-	each(lang, translations)
-
-	return nil
-}
-
-func readFromFile(path string, dataType DataType) (Tag, Translations, error) {
-	data, err := os.ReadFile(path)
-	if err != nil {
-		return Und, nil, fmt.Errorf("failed to read i18n file '%s': %w", path, err)
-	}
-
-	return readFromBytes(data, dataType)
+	return refs, errors.Join(errs...)
 }
 
-func readFromBytes(data []byte, dataType DataType) (Tag, Translations, error) {
-	return unmarshal(dataType, data)
+func readFromBytes(path string, data []byte, dataType DataType) (*translationsFile, error) {
+	return unmarshal(dataType, path, data)
 }
 
 func acceptFile(dataType DataType, name string) bool {
@@ -0,0 +1,368 @@
+package i18n_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/kukymbr/i18n"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type productDTO struct {
+	Title    string `i18n:"product.title"`
+	Category *categoryDTO
+	Badges   []string
+	Options  []optionDTO
+	Labels   map[string]string
+}
+
+type categoryDTO struct {
+	Name   string `i18n:"category.name"`
+	Parent *categoryDTO
+}
+
+type optionDTO struct {
+	Name string
+}
+
+func TestBundle_TranslateStruct_Nested(t *testing.T) {
+	source := i18n.FromString(i18n.YAML, `
+translations:
+  product.title: "Mug"
+  category.name: "Kitchen"
+  badge.new: "New"
+  option.color: "Color"
+  label.sale: "Sale"
+`)
+
+	newProduct := func() *productDTO {
+		category := &categoryDTO{}
+		category.Parent = category
+
+		return &productDTO{
+			Category: category,
+			Badges:   []string{"badge.new"},
+			Options:  []optionDTO{{Name: "option.color"}},
+			Labels:   map[string]string{"sale": "label.sale"},
+		}
+	}
+
+	tests := []struct {
+		Name     string
+		Sources  []i18n.BundleSource
+		Expected *productDTO
+	}{
+		{
+			Name:    "default",
+			Sources: []i18n.BundleSource{source},
+			Expected: &productDTO{
+				Title:   "Mug",
+				Badges:  []string{"badge.new"},
+				Options: []optionDTO{{Name: "Color"}},
+				Labels:  map[string]string{"sale": "label.sale"},
+			},
+		},
+		{
+			Name:    "with collections",
+			Sources: []i18n.BundleSource{source, i18n.WithStructCollections()},
+			Expected: &productDTO{
+				Title:   "Mug",
+				Badges:  []string{"New"},
+				Options: []optionDTO{{Name: "Color"}},
+				Labels:  map[string]string{"sale": "Sale"},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			bundle, err := i18n.NewBundle(i18n.English, test.Sources...)
+			require.NoError(t, err)
+
+			product := newProduct()
+
+			require.NoError(t, bundle.TranslateStruct(i18n.English, product))
+
+			assert.Equal(t, "Kitchen", product.Category.Name)
+			assert.Same(t, product.Category, product.Category.Parent)
+
+			product.Category = nil
+			assert.Equal(t, test.Expected, product)
+		})
+	}
+}
+
+type commentDTO struct {
+	Body string
+}
+
+type postDTO struct {
+	Title   string `i18n:"post.title"`
+	Comment commentDTO
+}
+
+func TestBundle_TranslateStruct_MissingKeys(t *testing.T) {
+	bundle, err := i18n.NewBundle(i18n.English, i18n.FromString(i18n.YAML, `
+translations:
+  post.title: "Post"
+  secret: "S3CRET"
+`))
+	require.NoError(t, err)
+
+	body := `{{ t "secret" }} {{ .Token }}`
+	post := &postDTO{Comment: commentDTO{Body: body}}
+
+	require.NoError(t, bundle.TranslateStruct(i18n.German, post, map[string]any{"Token": "tok-123"}))
+
+	assert.Equal(t, "Post", post.Title)
+	assert.Equal(t, body, post.Comment.Body, "values not found as keys are not rendered")
+}
+
+type orderStatus string
+
+func (s *orderStatus) TranslateI18n(tr i18n.Translator) error {
+	if *s == "" {
+		return errors.New("empty status")
+	}
+
+	*s = orderStatus(tr.T("status." + string(*s)))
+
+	return nil
+}
+
+type orderTitle string
+
+type orderPrice struct {
+	Amount   int
+	Currency string
+	Display  string
+}
+
+func (p orderPrice) TranslateI18n(_ i18n.Translator) error {
+	return nil
+}
+
+type orderTotal struct {
+	Amount  int
+	Display string
+}
+
+func (t *orderTotal) TranslateI18n(tr i18n.Translator) error {
+	t.Display = tr.T("order.total", i18n.Arg("Amount", t.Amount))
+
+	return nil
+}
+
+type orderDTO struct {
+	Title    orderTitle
+	Status   orderStatus
+	History  []orderStatus
+	Price    orderPrice
+	Total    *orderTotal
+	Customer string `i18n:"order.customer"`
+}
+
+func TestBundle_TranslateStruct_Translatable(t *testing.T) {
+	bundle, err := i18n.NewBundle(i18n.English, i18n.FromString(i18n.YAML, `
+translations:
+  order.title: "Order"
+  order.total: "Total: {{ .Amount }}"
+  order.customer: "Customer {{ .Name }}"
+  status.new: "New"
+  status.paid: "Paid"
+  price.display: "Price"
+`))
+	require.NoError(t, err)
+
+	order := &orderDTO{
+		Title:   "order.title",
+		Status:  "paid",
+		History: []orderStatus{"new", "paid"},
+		Price:   orderPrice{Amount: 10, Currency: "EUR", Display: "price.display"},
+		Total:   &orderTotal{Amount: 42},
+	}
+
+	require.NoError(t, bundle.TranslateStruct(i18n.English, order, i18n.Arg("Name", "Mateo")))
+
+	assert.Equal(t, &orderDTO{
+		Title:    "Order",
+		Status:   "Paid",
+		History:  []orderStatus{"New", "Paid"},
+		Price:    orderPrice{Amount: 10, Currency: "EUR", Display: "price.display"},
+		Total:    &orderTotal{Amount: 42, Display: "Total: 42"},
+		Customer: "Customer Mateo",
+	}, order)
+
+	t.Run("when translation fails", func(t *testing.T) {
+		order := &orderDTO{History: []orderStatus{"new", ""}}
+
+		err := bundle.TranslateStruct(i18n.English, order)
+
+		require.Error(t, err)
+		assert.Equal(t, []orderStatus{"New", ""}, order.History)
+	})
+}
+
+type orderLine struct {
+	Title string `i18n:"order.title"`
+	Order *orderNode
+}
+
+func (l *orderLine) TranslateI18n(tr i18n.Translator) error {
+	return tr.TranslateStruct(l)
+}
+
+type orderNode struct {
+	Status orderStatus
+	Lines  []*orderLine
+}
+
+func (n *orderNode) TranslateI18n(tr i18n.Translator) error {
+	if err := tr.TranslateStruct(n); err != nil {
+		return err
+	}
+
+	return tr.TranslateStruct(&orderDTO{Status: n.Status})
+}
+
+func TestBundle_TranslateStruct_TranslatableRecursion(t *testing.T) {
+	bundle, err := i18n.NewBundle(i18n.English, i18n.FromString(i18n.YAML, `
+translations:
+  order.title: "Order"
+  status.paid: "Paid"
+`))
+	require.NoError(t, err)
+
+	order := &orderNode{Status: "paid"}
+	order.Lines = []*orderLine{{Order: order}, {Order: order}}
+
+	require.NoError(t, bundle.TranslateStruct(i18n.English, order))
+
+	assert.Equal(t, orderStatus("Paid"), order.Status)
+	assert.Equal(t, "Order", order.Lines[0].Title)
+	assert.Equal(t, "Order", order.Lines[1].Title)
+
+	t.Run("when nested translation fails", func(t *testing.T) {
+		order := &orderNode{}
+
+		err := bundle.TranslateStruct(i18n.English, order)
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "empty status")
+	})
+}
+
+type apiErrorDTO struct {
+	_ struct{} `i18n:",prefix=errors."`
+
+	Code    string
+	Message string `i18n:",fromfield=Code,data=Params"`
+	Params  map[string]any
+	Hint    string `i18n:"hint"`
+}
+
+func TestBundle_TranslateStruct_TagOptions(t *testing.T) {
+	bundle, err := i18n.NewBundle(i18n.English, i18n.FromString(i18n.YAML, `
+translations:
+  errors:
+    not_found: "{{ .Entity }} not found"
+    hint: "Hello, {{ .Name }}"
+`))
+	require.NoError(t, err)
+
+	dto := &apiErrorDTO{
+		Code:   "not_found",
+		Params: map[string]any{"Entity": "Order"},
+	}
+
+	err = bundle.TranslateStruct(i18n.English, dto, map[string]any{"Name": "Mateo"})
+	require.NoError(t, err)
+
+	assert.Equal(t, "not_found", dto.Code)
+	assert.Equal(t, "Order not found", dto.Message)
+	assert.Equal(t, "Hello, Mateo", dto.Hint)
+}
+
+func TestBundle_TranslateSlice(t *testing.T) {
+	bundle, err := i18n.NewBundle(i18n.English, i18n.FromString(i18n.YAML, `
+translations:
+  option.color: "Color"
+  option.size: "Size"
+  status.new: "New"
+`))
+	require.NoError(t, err)
+
+	t.Run("slice of structs", func(t *testing.T) {
+		options := []optionDTO{{Name: "option.color"}, {Name: "option.size"}}
+
+		require.NoError(t, bundle.TranslateSlice(i18n.English, options))
+		assert.Equal(t, []optionDTO{{Name: "Color"}, {Name: "Size"}}, options)
+	})
+
+	t.Run("slice of pointers", func(t *testing.T) {
+		orders := []*orderDTO{{Status: "new"}, nil, {Status: ""}}
+
+		err := bundle.TranslateSlice(i18n.English, orders)
+
+		require.Error(t, err)
+		assert.Equal(t, orderStatus("New"), orders[0].Status)
+	})
+
+	t.Run("not a slice of structs", func(t *testing.T) {
+		require.Error(t, bundle.TranslateSlice(i18n.English, []string{"option.color"}))
+		require.Error(t, bundle.TranslateSlice(i18n.English, optionDTO{}))
+	})
+}
+
+func BenchmarkBundle_TranslateStruct(b *testing.B) {
+	bundle, err := i18n.NewBundle(i18n.English, i18n.FromString(i18n.YAML, `
+translations:
+  product.title: "Mug"
+  category.name: "Kitchen"
+  option.color: "Color"
+`))
+	require.NoError(b, err)
+
+	for b.Loop() {
+		product := &productDTO{
+			Category: &categoryDTO{},
+			Options:  []optionDTO{{Name: "option.color"}},
+		}
+
+		_ = bundle.TranslateStruct(i18n.English, product)
+	}
+}
+
+func BenchmarkBundle_TranslateSlice(b *testing.B) {
+	bundle, err := i18n.NewBundle(i18n.English, i18n.FromString(i18n.YAML, `
+translations:
+  option.color: "Color"
+`))
+	require.NoError(b, err)
+
+	options := make([]optionDTO, 1000)
+
+	for b.Loop() {
+		for i := range options {
+			options[i].Name = "option.color"
+		}
+
+		_ = bundle.TranslateSlice(i18n.English, options)
+	}
+}
+
+func TestBundle_GetTranslator(t *testing.T) {
+	bundle, err := i18n.NewBundle(i18n.English, i18n.FromEmbeddedFS(i18n.YAML, translationsFS, true, "testdata/example"))
+	require.NoError(t, err)
+
+	tr := bundle.GetTranslator(i18n.Spanish)
+
+	assert.Equal(t, i18n.Spanish, tr.Language())
+	assert.Equal(t, "¡Hola, Mateo!", tr.T("greeting.hello_name", map[string]any{"Name": "Mateo"}))
+
+	options := &productDTO{Options: []optionDTO{{Name: "greeting.hello"}}}
+
+	require.NoError(t, tr.TranslateStruct(options))
+	assert.Equal(t, "¡Hola!", options.Options[0].Name)
+}
@@ -2,57 +2,304 @@ package i18n
 
 import (
 	"bytes"
-	"html/template"
+	"fmt"
+	"hash/fnv"
+	htmltemplate "html/template"
+	"io"
+	"maps"
 	"strings"
 	"sync"
+	texttemplate "text/template"
 )
 
-var templateCache = struct {
+// Template engines available by default.
+var (
+	// HTMLEngine compiles texts using the html/template, escaping the data for the HTML output (default).
+	HTMLEngine TemplateEngine = htmlEngine{}
+	// TextEngine compiles texts using the text/template, leaving the data as is.
+	TextEngine TemplateEngine = textEngine{}
+)
+
+// TemplateEngine compiles translation texts into the executable templates.
+//
+// The engine could be set for the whole bundle using the WithTemplateEngine option
+// or for a single translation by passing it with the template data:
+// <code>
+// bundle.Translate(i18n.English, "greeting.hello_name", data, i18n.TextEngine)
+// </code>
+type TemplateEngine interface {
+	// Name returns a unique name of the engine, used to cache its templates.
+	Name() string
+	// Compile compiles the text into the Template.
+	Compile(name string, text string, opts TemplateOptions) (Template, error)
+}
+
+// TemplateOptions are the options of the template compilation.
+type TemplateOptions struct {
+	// Funcs are the functions available in the template,
+	// including the built-in ones bound to the requested language.
+	Funcs FuncMap
+	// Strict requires all the data used by the template to be present (missingkey=error).
+	Strict bool
+}
+
+// Template is a compiled translation text.
+type Template interface {
+	Execute(w io.Writer, data any) error
+}
+
+// WithTemplateEngine sets the TemplateEngine used by default to render translation texts.
+func WithTemplateEngine(engine TemplateEngine) BundleSource {
+	return func(b *Bundle) error {
+		b.cfg.engine = engine
+
+		return nil
+	}
+}
+
+type htmlEngine struct{}
+
+func (htmlEngine) Name() string {
+	return "html"
+}
+
+func (htmlEngine) Compile(name string, text string, opts TemplateOptions) (Template, error) {
+	tpl := htmltemplate.New(name).Funcs(htmltemplate.FuncMap(opts.Funcs))
+	if opts.Strict {
+		tpl = tpl.Option("missingkey=error")
+	}
+
+	return tpl.Parse(text)
+}
+
+type textEngine struct{}
+
+func (textEngine) Name() string {
+	return "text"
+}
+
+func (textEngine) Compile(name string, text string, opts TemplateOptions) (Template, error) {
+	tpl := texttemplate.New(name).Funcs(texttemplate.FuncMap(opts.Funcs))
+	if opts.Strict {
+		tpl = tpl.Option("missingkey=error")
+	}
+
+	return tpl.Parse(text)
+}
+
+// templateCache keeps compiled templates of the bundle's translations.
+// Templates are mapped by the language, key and hash of the text,
+// so the changed translation never gets the stale template.
+type templateCache struct {
 	mu        sync.RWMutex
-	templates map[string]*template.Template
-}{
-	templates: make(map[string]*template.Template),
+	templates map[templateKey]compiledTemplate
 }
 
-func prepareText(key string, text string, tplData any) string {
-	if strings.Contains(text, "{{") {
-		tpl := getTemplate(key, text)
-		if tpl == nil {
-			return text
-		}
+// compiledTemplate is a result of the text compilation.
+type compiledTemplate struct {
+	tpl Template
+	err error
+}
+
+type templateKey struct {
+	engine string
+	lang   Tag
+	// funcsLang is the language the built-in functions are bound to.
+	funcsLang Tag
+	key       string
+	textHash  uint64
+	depth     int
+}
+
+func newTemplateCache() *templateCache {
+	return &templateCache{
+		templates: make(map[templateKey]compiledTemplate),
+	}
+}
+
+func (c *templateCache) get(id templateKey) (compiledTemplate, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	tpl, ok := c.templates[id]
+
+	return tpl, ok
+}
+
+func (c *templateCache) set(id templateKey, tpl compiledTemplate) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.templates[id] = tpl
+}
+
+// resetLanguage removes the templates of the language and the ones bound to it.
+func (c *templateCache) resetLanguage(lang Tag) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	maps.DeleteFunc(c.templates, func(id templateKey, _ compiledTemplate) bool {
+		return id.lang == lang || id.funcsLang == lang
+	})
+}
 
-		var b bytes.Buffer
+func (c *templateCache) reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
 
-		err := tpl.Execute(&b, tplData)
-		if err != nil {
-			return text
+	c.templates = make(map[templateKey]compiledTemplate)
+}
+
+// translateParams are the parameters of the single translation.
+type translateParams struct {
+	data   any
+	engine TemplateEngine
+	// lang is the requested language, the built-in functions are bound to it.
+	// The language of the translation is used if empty.
+	lang Tag
+	// depth is the nesting level of the translation rendered by the `t` template function.
+	depth int
+}
+
+// parseTranslateParams splits the Translate's tplData arguments into the template data and options.
+// Argument values are combined into the template data.
+func (b *Bundle) parseTranslateParams(tplData []any) translateParams {
+	params := translateParams{engine: b.getEngine()}
+	dataFound := false
+
+	var args map[string]any
+
+	for _, v := range tplData {
+		switch v := v.(type) {
+		case TemplateEngine:
+			params.engine = v
+		case Argument:
+			if args == nil {
+				args = make(map[string]any)
+			}
+
+			args[v.name] = v.value
+		default:
+			if !dataFound {
+				params.data = v
+				dataFound = true
+			}
 		}
+	}
+
+	params.data = mergeArgs(params.data, args)
+
+	return params
+}
 
-		return b.String()
+func (b *Bundle) getEngine() TemplateEngine {
+	if b.cfg.engine != nil {
+		return b.cfg.engine
 	}
 
-	return text
+	return HTMLEngine
+}
+
+// prepareText renders the translation text.
+// If the template fails, the raw text is returned with the TemplateError.
+func (b *Bundle) prepareText(lang Tag, key string, text string, params translateParams) (string, error) {
+	if !isTemplate(text) {
+		return text, nil
+	}
+
+	tpl, err := b.getTemplate(params, lang, key, text)
+	if err != nil {
+		return text, b.newTemplateError(lang, key, err)
+	}
+
+	var buf bytes.Buffer
+
+	if err := tpl.Execute(&buf, params.data); err != nil {
+		return text, b.newTemplateError(lang, key, err)
+	}
+
+	return buf.String(), nil
+}
+
+func (b *Bundle) newTemplateError(lang Tag, key string, err error) *TemplateError {
+	tplErr := &TemplateError{Language: lang, Key: key, Err: err}
+	b.logTemplateError(tplErr)
+
+	return tplErr
 }
 
-func getTemplate(key string, text string) *template.Template {
-	var err error
+// getTemplate returns the compiled template of the text.
+// Compilation errors are cached as well as the templates.
+func (b *Bundle) getTemplate(params translateParams, lang Tag, key string, text string) (Template, error) {
+	funcsLang := params.lang
+	if funcsLang == Und {
+		funcsLang = lang
+	}
 
-	templateCache.mu.Lock()
-	defer templateCache.mu.Unlock()
+	id := templateKey{
+		engine:    params.engine.Name(),
+		lang:      lang,
+		funcsLang: funcsLang,
+		key:       key,
+		textHash:  hashText(text),
+		depth:     params.depth,
+	}
 
-	tpl, ok := templateCache.templates[key]
-	if ok {
-		return tpl
+	if compiled, ok := b.templates.get(id); ok {
+		return compiled.tpl, compiled.err
 	}
 
-	tpl = template.New(key)
+	opts := TemplateOptions{Funcs: b.getTemplateFuncs(funcsLang, params), Strict: b.cfg.strictTemplates}
 
-	tpl, err = tpl.Parse(text)
+	tpl, err := compileTemplate(params.engine, key, text, opts)
 	if err != nil {
-		return nil
+		tpl = nil
+	}
+
+	b.templates.set(id, compiledTemplate{tpl: tpl, err: err})
+
+	return tpl, err
+}
+
+// compileTemplate compiles the text, converting panics of the engine (e.g., on invalid functions) into errors.
+func compileTemplate(engine TemplateEngine, name string, text string, opts TemplateOptions) (tpl Template, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("failed to compile template %s: %v", name, r)
+		}
+	}()
+
+	return engine.Compile(name, text, opts)
+}
+
+// precompileTemplates compiles templates of all the language's translations,
+// so the first translation does not pay the parsing cost.
+func (b *Bundle) precompileTemplates(languages ...Tag) {
+	for _, lang := range languages {
+		b.mu.RLock()
+		texts := make(map[string]string)
+
+		for key, text := range b.translations[lang] {
+			if isTemplate(text) {
+				texts[key] = text
+			}
+		}
+
+		b.mu.RUnlock()
+
+		for key, text := range texts {
+			_, _ = b.getTemplate(translateParams{engine: b.getEngine()}, lang, key, text)
+		}
 	}
+}
+
+func isTemplate(text string) bool {
+	return strings.Contains(text, "{{")
+}
 
-	templateCache.templates[key] = tpl
+func hashText(text string) uint64 {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(text))
 
-	return tpl
+	return h.Sum64()
 }
@@ -0,0 +1,343 @@
+package i18n_test
+
+import (
+	"bytes"
+	"io"
+	"log"
+	"os"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kukymbr/i18n"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBundle_TemplatesCache(t *testing.T) {
+	data := struct{ Name string }{"Mateo"}
+
+	bundle1, err := i18n.NewBundle(i18n.English, i18n.FromEmbeddedFS(i18n.YAML, translationsFS, true, "testdata/example"))
+	require.NoError(t, err)
+
+	bundle2, err := i18n.NewBundle(
+		i18n.English,
+		i18n.FromString(i18n.YAML, "translations: {greeting.hello_name: 'Hi there, {{ .Name }}!'}"),
+	)
+	require.NoError(t, err)
+
+	for range 2 {
+		assert.Equal(t, "Hello, Mateo!", bundle1.T(i18n.English, "greeting.hello_name", data))
+		assert.Equal(t, "¡Hola, Mateo!", bundle1.T(i18n.Spanish, "greeting.hello_name", data))
+		assert.Equal(t, "Hi there, Mateo!", bundle2.T(i18n.English, "greeting.hello_name", data))
+	}
+
+	t.Run("when translation is changed", func(t *testing.T) {
+		err := bundle2.AddLayer("patch", i18n.PriorityRuntime,
+			i18n.FromString(i18n.YAML, "translations: {greeting.hello_name: 'Welcome, {{ .Name }}!'}"),
+		)
+		require.NoError(t, err)
+
+		assert.Equal(t, "Welcome, Mateo!", bundle2.T(i18n.English, "greeting.hello_name", data))
+
+		bundle2.RemoveLayer("patch")
+
+		assert.Equal(t, "Hi there, Mateo!", bundle2.T(i18n.English, "greeting.hello_name", data))
+	})
+
+	t.Run("when template is invalid", func(t *testing.T) {
+		bundle, err := i18n.NewBundle(i18n.English, i18n.FromString(i18n.YAML, "translations: {broken: 'Hello, {{ .Name '}"))
+		require.NoError(t, err)
+
+		assert.Equal(t, "Hello, {{ .Name ", bundle.T(i18n.English, "broken", data))
+	})
+}
+
+type upperEngine struct{}
+
+func (upperEngine) Name() string {
+	return "upper"
+}
+
+func (upperEngine) Compile(_ string, text string, _ i18n.TemplateOptions) (i18n.Template, error) {
+	return upperTemplate(text), nil
+}
+
+type upperTemplate string
+
+func (t upperTemplate) Execute(w io.Writer, _ any) error {
+	_, err := io.WriteString(w, strings.ToUpper(string(t)))
+
+	return err
+}
+
+func TestBundle_TemplateEngines(t *testing.T) {
+	data := struct{ Name string }{"O'Brien"}
+	source := i18n.FromString(i18n.YAML, "translations: {hello: 'Hello, {{ .Name }}!'}")
+
+	tests := []struct {
+		Name     string
+		Engine   i18n.TemplateEngine
+		Default  string
+		PerCall  i18n.TemplateEngine
+		Expected string
+	}{
+		{
+			Name:     "default engine",
+			Default:  "Hello, O&#39;Brien!",
+			PerCall:  i18n.TextEngine,
+			Expected: "Hello, O'Brien!",
+		},
+		{
+			Name:     "text engine",
+			Engine:   i18n.TextEngine,
+			Default:  "Hello, O'Brien!",
+			PerCall:  i18n.HTMLEngine,
+			Expected: "Hello, O&#39;Brien!",
+		},
+		{
+			Name:     "custom engine",
+			Engine:   upperEngine{},
+			Default:  "HELLO, {{ .NAME }}!",
+			PerCall:  i18n.TextEngine,
+			Expected: "Hello, O'Brien!",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			sources := []i18n.BundleSource{source}
+			if test.Engine != nil {
+				sources = append(sources, i18n.WithTemplateEngine(test.Engine))
+			}
+
+			bundle, err := i18n.NewBundle(i18n.English, sources...)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.Default, bundle.T(i18n.English, "hello", data))
+			assert.Equal(t, test.Expected, bundle.T(i18n.English, "hello", test.PerCall, data))
+			assert.Equal(t, test.Expected, bundle.T(i18n.English, "hello", data, test.PerCall))
+		})
+	}
+}
+
+func TestBundle_TemplateFuncs(t *testing.T) {
+	translations := i18n.Translations{
+		"app_name": "Acme",
+		"welcome":  `Welcome to {{ t "app_name" }}, {{ .Name | default "friend" }}!`,
+		"nested":   `{{ t "welcome" . }}`,
+		"loop":     `{{ t "loop" }}`,
+		"shout":    `{{ upper .Name }}`,
+		"whisper":  `{{ lower .Name }}`,
+		"amount":   `{{ number .Amount }}`,
+		"date":     `{{ .Date | date "02.01.2006" }}`,
+		"items":    `{{ .Count }} {{ plural .Count "one=item" "other=items" }}`,
+		"apples":   `{{ .Count }} {{ plural .Count "one=яблоко" "few=яблока" "many=яблок" "other=яблока" }}`,
+		"custom":   `{{ reverse .Name }}`,
+		"tagged":   `{{ lang }}`,
+	}
+
+	sources := make([]i18n.BundleSource, 0, 7)
+
+	for _, lang := range []i18n.Tag{i18n.English, i18n.German, i18n.Turkish, i18n.Russian} {
+		sources = append(sources, i18n.FromFunc(func() (i18n.Tag, i18n.Translations, error) {
+			return lang, translations, nil
+		}))
+	}
+
+	sources = append(sources,
+		i18n.WithTemplateEngine(i18n.TextEngine),
+		i18n.WithTemplateFuncs(i18n.FuncMap{
+			"reverse": func(s string) string {
+				r := []rune(s)
+				slices.Reverse(r)
+
+				return string(r)
+			},
+		}),
+		i18n.WithLanguageTemplateFuncs(func(lang i18n.Tag) i18n.FuncMap {
+			return i18n.FuncMap{"lang": lang.String}
+		}),
+	)
+
+	bundle, err := i18n.NewBundle(i18n.English, sources...)
+	require.NoError(t, err)
+
+	date := time.Date(2025, time.March, 8, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		Lang     i18n.Tag
+		Key      string
+		Data     any
+		Expected string
+	}{
+		{i18n.English, "welcome", map[string]any{"Name": "Mateo"}, "Welcome to Acme, Mateo!"},
+		{i18n.English, "welcome", map[string]any{}, "Welcome to Acme, friend!"},
+		{i18n.English, "nested", map[string]any{"Name": "Mateo"}, "Welcome to Acme, Mateo!"},
+		{i18n.English, "loop", nil, `{{ t "loop" }}`},
+		{i18n.English, "shout", map[string]any{"Name": "istanbul"}, "ISTANBUL"},
+		{i18n.Turkish, "shout", map[string]any{"Name": "istanbul"}, "İSTANBUL"},
+		{i18n.English, "whisper", map[string]any{"Name": "MATEO"}, "mateo"},
+		{i18n.English, "amount", map[string]any{"Amount": 1234.5}, "1,234.5"},
+		{i18n.German, "amount", map[string]any{"Amount": 1234.5}, "1.234,5"},
+		{i18n.English, "amount", map[string]any{"Amount": "many"}, "{{ number .Amount }}"},
+		{i18n.English, "date", map[string]any{"Date": date}, "08.03.2025"},
+		{i18n.English, "items", map[string]any{"Count": 1}, "1 item"},
+		{i18n.English, "items", map[string]any{"Count": 5}, "5 items"},
+		{i18n.English, "items", map[string]any{"Count": 1.5}, "1.5 items"},
+		{i18n.Russian, "apples", map[string]any{"Count": 1}, "1 яблоко"},
+		{i18n.Russian, "apples", map[string]any{"Count": 3}, "3 яблока"},
+		{i18n.Russian, "apples", map[string]any{"Count": 11}, "11 яблок"},
+		{i18n.English, "custom", map[string]any{"Name": "Mateo"}, "oetaM"},
+		{i18n.German, "tagged", nil, "de"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Lang.String()+"/"+test.Key, func(t *testing.T) {
+			assert.Equal(t, test.Expected, bundle.T(test.Lang, test.Key, test.Data))
+		})
+	}
+
+	t.Run("when text falls back to another language", func(t *testing.T) {
+		bundle, err := i18n.NewBundle(
+			i18n.English,
+			i18n.FromString(i18n.YAML, `
+language: en
+translations:
+  app: App
+  welcome: "Welcome to {{ t \"app\" }}, {{ number .Amount }}"
+`),
+			i18n.FromString(i18n.YAML, "language: de\ntranslations: {app: App-DE}"),
+		)
+		require.NoError(t, err)
+
+		data := map[string]any{"Amount": 1234.5}
+
+		assert.Equal(t, "Welcome to App, 1,234.5", bundle.T(i18n.English, "welcome", data))
+		assert.Equal(t, "Welcome to App-DE, 1.234,5", bundle.T(i18n.German, "welcome", data))
+	})
+
+	t.Run("when nested translation is escaped", func(t *testing.T) {
+		bundle, err := i18n.NewBundle(i18n.English, i18n.FromString(i18n.YAML, `
+translations:
+  name: "{{ .Name }}"
+  hello: "Hello, {{ t \"name\" . }}!"
+`))
+		require.NoError(t, err)
+
+		assert.Equal(t, "Hello, O&#39;Brien!", bundle.T(i18n.English, "hello", map[string]any{"Name": "O'Brien"}))
+	})
+
+	t.Run("when nested key from data is missing", func(t *testing.T) {
+		bundle, err := i18n.NewBundle(i18n.English, i18n.FromString(i18n.YAML, `
+translations:
+  error: "Error: {{ t .Code }}"
+`))
+		require.NoError(t, err)
+
+		tests := []struct {
+			Code     string
+			Expected string
+		}{
+			{`<img src=x onerror=alert(1)>`, "Error: &lt;img src=x onerror=alert(1)&gt;"},
+			{`{{ .Secret }}`, "Error: {{ .Secret }}"},
+		}
+
+		for _, test := range tests {
+			data := map[string]any{"Code": test.Code, "Secret": "leaked"}
+
+			assert.Equal(t, test.Expected, bundle.T(i18n.English, "error", data))
+		}
+	})
+
+	t.Run("when function is invalid", func(t *testing.T) {
+		_, err := i18n.NewBundle(i18n.English, i18n.WithTemplateFuncs(i18n.FuncMap{"invalid": "not a function"}))
+
+		require.Error(t, err)
+	})
+}
+
+func TestBundle_TranslateE(t *testing.T) {
+	source := i18n.FromString(i18n.YAML, `
+translations:
+  hello: "Hello, {{ .Name }}!"
+  broken: "Hello, {{ .Name "
+  nested: "{{ t \"broken\" }}"
+`)
+
+	tests := []struct {
+		Name     string
+		Strict   bool
+		Key      string
+		Data     any
+		Expected string
+		Failed   bool
+	}{
+		{Name: "valid", Key: "hello", Data: map[string]any{"Name": "Mateo"}, Expected: "Hello, Mateo!"},
+		{Name: "missing map key", Key: "hello", Data: map[string]any{}, Expected: "Hello, !"},
+		{Name: "missing map key in strict mode", Strict: true, Key: "hello", Data: map[string]any{}, Failed: true},
+		{Name: "missing struct field", Key: "hello", Data: struct{ Title string }{}, Failed: true},
+		{Name: "syntax error", Key: "broken", Failed: true},
+		{Name: "syntax error of nested", Key: "nested", Failed: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			var handled []error
+
+			sources := []i18n.BundleSource{
+				source,
+				i18n.WithErrorHandler(func(err error) {
+					handled = append(handled, err)
+				}),
+			}
+			if test.Strict {
+				sources = append(sources, i18n.WithStrictTemplates())
+			}
+
+			bundle, err := i18n.NewBundle(i18n.English, sources...)
+			require.NoError(t, err)
+
+			text, err := bundle.TranslateE(i18n.English, test.Key, test.Data)
+			assert.Empty(t, handled)
+
+			if !test.Failed {
+				require.NoError(t, err)
+				assert.Equal(t, test.Expected, text)
+
+				return
+			}
+
+			var tplErr *i18n.TemplateError
+
+			require.ErrorAs(t, err, &tplErr)
+			assert.Equal(t, i18n.English, tplErr.Language)
+			assert.Equal(t, test.Key, tplErr.Key)
+
+			assert.Equal(t, text, bundle.T(i18n.English, test.Key, test.Data))
+			require.Len(t, handled, 1)
+			assert.Equal(t, err.Error(), handled[0].Error())
+		})
+	}
+}
+
+func TestBundle_DefaultErrorLogging(t *testing.T) {
+	var out bytes.Buffer
+
+	log.SetOutput(&out)
+	t.Cleanup(func() {
+		log.SetOutput(os.Stderr)
+	})
+
+	bundle, err := i18n.NewBundle(i18n.English, i18n.FromString(i18n.YAML, "translations: {broken: '{{ .Name ', other: '{{ .Name '}"))
+	require.NoError(t, err)
+
+	for range 3 {
+		assert.Equal(t, "{{ .Name ", bundle.T(i18n.English, "broken"))
+		assert.Equal(t, "{{ .Name ", bundle.T(i18n.English, "other"))
+	}
+
+	assert.Equal(t, 1, strings.Count(out.String(), "template of key broken"), "logged once per language and key")
+	assert.Equal(t, 1, strings.Count(out.String(), "template of key other"))
+}
@@ -0,0 +1,145 @@
+package i18n
+
+import (
+	"container/list"
+	"fmt"
+	"sync"
+)
+
+// Derive creates a lightweight bundle on top of the bundle b,
+// containing only translations from the given sources.
+// Keys missing in the derived bundle are resolved using the base bundle,
+// so the base translations are shared and never copied.
+// The derived bundle inherits options of the base one.
+func (b *Bundle) Derive(sources ...BundleSource) (*Bundle, error) {
+	derived := newBundle(b.fallbackLanguage)
+	derived.cfg = b.cfg
+	derived.parent = b
+
+	if err := derived.load(sources...); err != nil {
+		return nil, err
+	}
+
+	return derived, nil
+}
+
+// GetBase returns the base bundle of the derived one or nil if the bundle is not derived.
+func (b *Bundle) GetBase() *Bundle {
+	return b.parent
+}
+
+// TenantSourcesFunc returns sources of the tenant-specific translations.
+type TenantSourcesFunc func(tenantID string) ([]BundleSource, error)
+
+// TenantRegistry keeps the tenant-specific bundles derived from the shared base bundle.
+// Number of kept bundles is limited, the least recently used ones are evicted first.
+type TenantRegistry struct {
+	base    *Bundle
+	size    int
+	sources TenantSourcesFunc
+
+	mu      sync.Mutex
+	items   map[string]*list.Element
+	recents *list.List
+}
+
+type tenantEntry struct {
+	id     string
+	bundle *Bundle
+}
+
+// NewTenantRegistry creates a new TenantRegistry keeping up to size tenant bundles.
+// The size less than 1 means no limit.
+func NewTenantRegistry(base *Bundle, size int, sources TenantSourcesFunc) *TenantRegistry {
+	if base == nil {
+		base = NewEmptyBundle()
+	}
+
+	return &TenantRegistry{
+		base:    base,
+		size:    size,
+		sources: sources,
+		items:   make(map[string]*list.Element),
+		recents: list.New(),
+	}
+}
+
+// Get returns the tenant's bundle, deriving it from the base bundle if it is not registered yet.
+func (r *TenantRegistry) Get(tenantID string) (*Bundle, error) {
+	r.mu.Lock()
+
+	if elem, ok := r.items[tenantID]; ok {
+		r.recents.MoveToFront(elem)
+
+		entry, _ := elem.Value.(*tenantEntry)
+		r.mu.Unlock()
+
+		return entry.bundle, nil
+	}
+
+	r.mu.Unlock()
+
+	var sources []BundleSource
+
+	if r.sources != nil {
+		var err error
+
+		sources, err = r.sources(tenantID)
+		if err != nil {
+			return nil, fmt.Errorf("get sources of the tenant %s: %w", tenantID, err)
+		}
+	}
+
+	bundle, err := r.base.Derive(sources...)
+	if err != nil {
+		return nil, fmt.Errorf("derive bundle of the tenant %s: %w", tenantID, err)
+	}
+
+	return r.put(tenantID, bundle), nil
+}
+
+// Remove removes the tenant's bundle from the registry,
+// so it is derived again on the next Get call.
+func (r *TenantRegistry) Remove(tenantID string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if elem, ok := r.items[tenantID]; ok {
+		r.recents.Remove(elem)
+		delete(r.items, tenantID)
+	}
+}
+
+// Len returns the number of the registered tenant bundles.
+func (r *TenantRegistry) Len() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.recents.Len()
+}
+
+// put registers the tenant's bundle unless it has been registered concurrently,
+// and returns the registered one.
+func (r *TenantRegistry) put(tenantID string, bundle *Bundle) *Bundle {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if elem, ok := r.items[tenantID]; ok {
+		r.recents.MoveToFront(elem)
+
+		entry, _ := elem.Value.(*tenantEntry)
+
+		return entry.bundle
+	}
+
+	r.items[tenantID] = r.recents.PushFront(&tenantEntry{id: tenantID, bundle: bundle})
+
+	for r.size > 0 && r.recents.Len() > r.size {
+		oldest := r.recents.Back()
+
+		entry, _ := r.recents.Remove(oldest).(*tenantEntry)
+		delete(r.items, entry.id)
+	}
+
+	return bundle
+}
@@ -0,0 +1,113 @@
+package i18n_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/kukymbr/i18n"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBundle_Derive(t *testing.T) {
+	base, err := i18n.NewBundle(i18n.English, i18n.FromDirs(i18n.YAML, false, "testdata/yaml"))
+	require.NoError(t, err)
+
+	baseHash := base.CalcHash()
+
+	derived, err := base.Derive(
+		i18n.FromString(i18n.YAML, "language: es\ntranslations: {test_1: Prueba del inquilino}"),
+		i18n.FromString(i18n.YAML, "language: fr\ntranslations: {test_1: Test du locataire}"),
+	)
+	require.NoError(t, err)
+	assert.Same(t, base, derived.GetBase())
+	assert.Nil(t, base.GetBase())
+
+	assert.Equal(t, "Prueba del inquilino", derived.T(i18n.Spanish, "test_1"))
+	assert.Equal(t, "Test du locataire", derived.T(i18n.French, "test_1"))
+	assert.Equal(t, "Prueba 2 en YAML", derived.T(i18n.Spanish, "test_2"))
+	assert.Equal(t, "Test 1 in YAML", derived.T(i18n.English, "test_1"))
+	assert.Equal(t, "Prueba 1 en YAML", base.T(i18n.Spanish, "test_1"))
+
+	origin, ok := derived.GetTranslationOrigin(i18n.English, "errors.test_4")
+	require.True(t, ok)
+	assert.Equal(t, "testdata/yaml/en.yml", origin.Path)
+
+	t.Run("hash", func(t *testing.T) {
+		hash := derived.CalcHash()
+
+		assert.NotEqual(t, baseHash, hash)
+		assert.Equal(t, baseHash, base.CalcHash())
+
+		require.NoError(t, base.AddLayer("patch", i18n.PriorityRuntime, i18n.FromString(i18n.YAML, "translations: {test_1: Patched}")))
+
+		assert.NotEqual(t, hash, derived.CalcHash())
+		assert.Equal(t, "Patched", derived.T(i18n.English, "test_1"))
+	})
+
+	t.Run("export", func(t *testing.T) {
+		export := derived.GetBundleExport()
+
+		assert.Equal(t, derived.CalcHash(), export.ETag)
+		require.Len(t, export.Languages, 3)
+		assert.Equal(t, i18n.English, export.Languages[0].Language)
+		assert.Equal(t, i18n.Spanish, export.Languages[1].Language)
+		assert.Equal(t, i18n.French, export.Languages[2].Language)
+		assert.Len(t, export.Languages[0].Translations, 6)
+		assert.Equal(t, i18n.Translations{
+			"test_1": "Prueba del inquilino",
+			"test_2": "Prueba 2 en YAML",
+			"test_3": "Prueba {{ .TestN }} en YAML",
+		}, export.Languages[1].Translations)
+	})
+}
+
+func TestTenantRegistry(t *testing.T) {
+	base, err := i18n.NewBundle(i18n.English, i18n.FromString(i18n.YAML, "translations: {title: Base title, subtitle: Subtitle}"))
+	require.NoError(t, err)
+
+	loads := make(map[string]int)
+
+	registry := i18n.NewTenantRegistry(base, 2, func(tenantID string) ([]i18n.BundleSource, error) {
+		loads[tenantID]++
+
+		if tenantID == "broken" {
+			return nil, errors.New("test error")
+		}
+
+		return []i18n.BundleSource{
+			i18n.FromString(i18n.YAML, "translations: {title: "+tenantID+" title}"),
+		}, nil
+	})
+
+	acme, err := registry.Get("acme")
+	require.NoError(t, err)
+	assert.Equal(t, "acme title", acme.T(i18n.English, "title"))
+	assert.Equal(t, "Subtitle", acme.T(i18n.English, "subtitle"))
+
+	globex, err := registry.Get("globex")
+	require.NoError(t, err)
+	assert.Equal(t, "globex title", globex.T(i18n.English, "title"))
+	assert.NotEqual(t, acme.CalcHash(), globex.CalcHash())
+
+	again, err := registry.Get("acme")
+	require.NoError(t, err)
+	assert.Same(t, acme, again)
+	assert.Equal(t, 1, loads["acme"])
+
+	_, err = registry.Get("initech")
+	require.NoError(t, err)
+	assert.Equal(t, 2, registry.Len())
+
+	// The least recently used globex is evicted.
+	_, err = registry.Get("globex")
+	require.NoError(t, err)
+	assert.Equal(t, 2, loads["globex"])
+
+	registry.Remove("globex")
+	assert.Equal(t, 1, registry.Len())
+
+	_, err = registry.Get("broken")
+	require.Error(t, err)
+	assert.Equal(t, 1, registry.Len())
+}
@@ -0,0 +1,122 @@
+package i18n
+
+import (
+	"reflect"
+
+	"github.com/kukymbr/i18n/internal/tagsparser"
+)
+
+var translatableType = reflect.TypeFor[Translatable]()
+
+// Translatable is implemented by the types translating themselves,
+// e.g. domain enums or value objects.
+// TranslateStruct calls the TranslateI18n on the values and fields implementing it
+// instead of translating them; fields of such structures are not traversed.
+// Calling the Translator.TranslateStruct on the value itself translates its fields
+// without calling the TranslateI18n again, the visited pointers are shared with the outer call.
+type Translatable interface {
+	TranslateI18n(tr Translator) error
+}
+
+// Translator translates keys to the single language,
+// see the Bundle.GetTranslator and the Translatable.
+type Translator interface {
+	// Language returns the target language.
+	Language() Tag
+	// T translates the key. If no tplData is given, template data of the TranslateStruct call is used.
+	T(key string, tplData ...any) string
+	// TranslateStruct translates the nested structure, see the Bundle.TranslateStruct.
+	TranslateStruct(structure any) error
+}
+
+// GetTranslator returns the Translator to the language.
+func (b *Bundle) GetTranslator(lang Tag) Translator {
+	return &translator{bundle: b, lang: lang}
+}
+
+type translator struct {
+	bundle  *Bundle
+	lang    Tag
+	tplData []any
+	// walk continues the TranslateStruct call, which passed the translator to the TranslateI18n.
+	walk func(structure any) error
+}
+
+func (tr *translator) Language() Tag {
+	return tr.lang
+}
+
+func (tr *translator) T(key string, tplData ...any) string {
+	if len(tplData) == 0 {
+		tplData = tr.tplData
+	}
+
+	return tr.bundle.Translate(tr.lang, key, tplData...)
+}
+
+func (tr *translator) TranslateStruct(structure any) error {
+	if tr.walk == nil {
+		return tr.bundle.TranslateStruct(tr.lang, structure, tr.tplData...)
+	}
+
+	// Errors are wrapped by the outer call.
+	return tr.walk(structure)
+}
+
+// update translates the key of the structure's field.
+func (tr *translator) update(key string, data []any) string {
+	if data != nil {
+		// Field's data takes precedence, while engine and arguments of the call are still applied.
+		return tr.bundle.Translate(tr.lang, key, append(data, tr.tplData...)...)
+	}
+
+	return tr.bundle.Translate(tr.lang, key, tr.tplData...)
+}
+
+// translateCustom calls the TranslateI18n if the value implements the Translatable.
+func (tr *translator) translateCustom(v reflect.Value, walk func(structure any) error) (bool, error) {
+	target, ok := asTranslatable(v)
+	if !ok {
+		return false, nil
+	}
+
+	nested := *tr
+	nested.walk = walk
+
+	return true, target.TranslateI18n(&nested)
+}
+
+// asTranslatable returns the Translatable of the value or of its address.
+func asTranslatable(v reflect.Value) (Translatable, bool) {
+	if (v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface) && v.IsNil() {
+		return nil, false
+	}
+
+	if v.Kind() != reflect.Ptr && v.CanAddr() && v.Addr().CanInterface() {
+		if target, ok := v.Addr().Interface().(Translatable); ok {
+			return target, true
+		}
+	}
+
+	if !v.CanInterface() {
+		return nil, false
+	}
+
+	target, ok := v.Interface().(Translatable)
+
+	return target, ok
+}
+
+// getStructParser returns the parser of the structures, caching their reflection plans.
+func (b *Bundle) getStructParser() *tagsparser.Parser {
+	b.structParserOnce.Do(func() {
+		b.structParser = tagsparser.NewParser(tagsparser.Options{
+			Collections: b.cfg.structCollections,
+			HookType: func(t reflect.Type) bool {
+				return t.Implements(translatableType)
+			},
+		})
+	})
+
+	return b.structParser
+}
@@ -0,0 +1,52 @@
+package i18n
+
+import (
+	"errors"
+	"strings"
+)
+
+// WithValidation makes the NewBundle validate templates of all the loaded translations,
+// returning the TemplateError instances of the broken ones (see Bundle.Validate).
+// Lazy files (see WithLazyLoading) are loaded too, so the NewBundle fails on their loading errors.
+func WithValidation() BundleSource {
+	return func(b *Bundle) error {
+		b.cfg.validate = true
+
+		return nil
+	}
+}
+
+// Validate compiles templates of all the translations in all the languages with the bundle's TemplateEngine.
+// Returns joined TemplateError instances with the source positions of the broken translations,
+// sorted by the language and key.
+//
+// Note that all the registered lazy files are loaded by the validation.
+func (b *Bundle) Validate() error {
+	b.ensureAllLanguages()
+
+	var errs []error
+
+	params := translateParams{engine: b.getEngine()}
+
+	for _, lang := range b.getLanguages() {
+		translations, _ := b.getLanguageTranslations(lang)
+
+		for _, key := range getSortedKeys(translations, strings.Compare) {
+			text := translations[key]
+			if !isTemplate(text) {
+				continue
+			}
+
+			if _, err := b.getTemplate(params, lang, key, text); err != nil {
+				tplErr := &TemplateError{Language: lang, Key: key, Err: err}
+				if origin, ok := b.GetTranslationOrigin(lang, key); ok {
+					tplErr.Position = origin.Position
+				}
+
+				errs = append(errs, tplErr)
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,71 @@
+package i18n_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/kukymbr/i18n"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBundle_Validate(t *testing.T) {
+	dir := t.TempDir()
+
+	writeTestFile(t, dir, "messages.en.yaml", `
+language: en
+translations:
+  hello: "Hello, {{ .Name }}!"
+  bye: "Bye, {{ .Name }}!"
+`)
+	writeTestFile(t, dir, "messages.es.yaml", `
+language: es
+translations:
+  hello: "¡Hola, {{ .Name }!"
+  bye: "Adiós, {{ .Name | unknown }}!"
+`)
+
+	source := i18n.FromDirs(i18n.YAML, false, dir)
+
+	t.Run("when validated explicitly", func(t *testing.T) {
+		bundle, err := i18n.NewBundle(i18n.English, source)
+		require.NoError(t, err)
+
+		errs := i18n.GetTemplateErrors(bundle.Validate())
+		require.Len(t, errs, 2)
+
+		expected := []struct {
+			Key  string
+			Line int
+		}{
+			{"bye", 5},
+			{"hello", 4},
+		}
+
+		for i, e := range expected {
+			assert.Equal(t, i18n.Spanish, errs[i].Language)
+			assert.Equal(t, e.Key, errs[i].Key)
+			assert.Equal(t, filepath.Join(dir, "messages.es.yaml"), errs[i].Position.Path)
+			assert.Equal(t, e.Line, errs[i].Position.Line)
+		}
+	})
+
+	t.Run("when validated by the NewBundle", func(t *testing.T) {
+		_, err := i18n.NewBundle(i18n.English, i18n.WithValidation(), source)
+
+		require.Error(t, err)
+		assert.Len(t, i18n.GetTemplateErrors(err), 2)
+	})
+
+	t.Run("when templates are valid", func(t *testing.T) {
+		bundle, err := i18n.NewBundle(
+			i18n.English,
+			i18n.WithValidation(),
+			i18n.WithLazyLoading(0),
+			i18n.FromEmbeddedFS(i18n.YAML, translationsFS, true, "testdata/example"),
+		)
+		require.NoError(t, err)
+
+		assert.NoError(t, bundle.Validate())
+	})
+}